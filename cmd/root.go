@@ -3,6 +3,8 @@ package cmd
 import (
 	"context"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/creasty/defaults"
 	"github.com/ethpandaops/checkpointz/pkg/checkpointz"
@@ -17,8 +19,17 @@ var rootCmd = &cobra.Command{
 	Short: "Checkpoint sync provider for Ethereum beacon nodes",
 	Run: func(cmd *cobra.Command, args []string) {
 		cfg := initCommon()
+
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
 		p := checkpointz.NewServer(log, cfg)
-		if err := p.Start(context.Background()); err != nil {
+
+		p.SetConfigLoader(func() (*checkpointz.Config, error) {
+			return loadConfigFromFile(cfgFile)
+		})
+
+		if err := p.Start(ctx); err != nil {
 			log.WithError(err).Fatal("failed to serve")
 		}
 	},
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/checkpointz/pkg/beacon"
+)
+
+const (
+	// finalizedMaxAge is the shared cache max age for finalized data that is addressed by an immutable identifier.
+	finalizedMaxAge = "public, s-max-age=6000"
+)
+
+// NewFinalizedCacheControl returns the cache-control header for finalized data from a slot that started at slotTime.
+// Data is only marked as immutable once it's older than the weak subjectivity period as a deep reorg is practically
+// impossible at that point. Newer finalized data is allowed to be served stale while it's revalidated.
+func NewFinalizedCacheControl(config beacon.CacheControlConfig, slotTime, now time.Time) string {
+	if config.Immutable && now.Sub(slotTime) > config.WeakSubjectivityPeriod.Duration {
+		return finalizedMaxAge + ", immutable"
+	}
+
+	if config.StaleWhileRevalidate.Duration > 0 {
+		return fmt.Sprintf("%s, stale-while-revalidate=%d", finalizedMaxAge, int64(config.StaleWhileRevalidate.Seconds()))
+	}
+
+	return finalizedMaxAge
+}
+
+func (h *Handler) finalizedCacheControl(ctx context.Context, slot phase0.Slot) string {
+	slotTime, err := h.checkpointz.SlotTime(ctx, slot)
+	if err != nil {
+		// We can't tell how old the data is so play it safe.
+		return NewFinalizedCacheControl(beacon.CacheControlConfig{
+			StaleWhileRevalidate: h.cacheControl.StaleWhileRevalidate,
+		}, time.Time{}, time.Time{})
+	}
+
+	return NewFinalizedCacheControl(h.cacheControl, slotTime.StartTime, time.Now())
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/checkpointz/pkg/beacon"
+	"github.com/ethpandaops/checkpointz/pkg/human"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFinalizedCacheControl(t *testing.T) {
+	now := time.Now()
+
+	config := beacon.CacheControlConfig{
+		Immutable:              true,
+		WeakSubjectivityPeriod: human.Duration{Duration: 14 * 24 * time.Hour},
+		StaleWhileRevalidate:   human.Duration{Duration: time.Hour},
+	}
+
+	tests := []struct {
+		name     string
+		config   beacon.CacheControlConfig
+		slotTime time.Time
+		expected string
+	}{
+		{
+			name:     "older than weak subjectivity period",
+			config:   config,
+			slotTime: now.Add(-15 * 24 * time.Hour),
+			expected: "public, s-max-age=6000, immutable",
+		},
+		{
+			name:     "newer than weak subjectivity period",
+			config:   config,
+			slotTime: now.Add(-time.Hour),
+			expected: "public, s-max-age=6000, stale-while-revalidate=3600",
+		},
+		{
+			name: "immutable disabled",
+			config: beacon.CacheControlConfig{
+				WeakSubjectivityPeriod: config.WeakSubjectivityPeriod,
+				StaleWhileRevalidate:   config.StaleWhileRevalidate,
+			},
+			slotTime: now.Add(-15 * 24 * time.Hour),
+			expected: "public, s-max-age=6000, stale-while-revalidate=3600",
+		},
+		{
+			name: "stale while revalidate disabled",
+			config: beacon.CacheControlConfig{
+				Immutable:              true,
+				WeakSubjectivityPeriod: config.WeakSubjectivityPeriod,
+			},
+			slotTime: now.Add(-time.Hour),
+			expected: "public, s-max-age=6000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewFinalizedCacheControl(tt.config, tt.slotTime, now))
+		})
+	}
+}
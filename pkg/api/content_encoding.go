@@ -0,0 +1,73 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	ContentEncodingIdentity = "identity"
+	ContentEncodingGzip     = "gzip"
+)
+
+// SupportedContentEncodings are the content encodings that can be served, in order of preference when the client
+// has no preference between them.
+var SupportedContentEncodings = []string{ContentEncodingGzip}
+
+// NegotiateContentEncoding returns the supported content encoding with the highest q-value in the Accept-Encoding
+// header. Identity is returned if the client doesn't accept any of the supported encodings, including when it has
+// explicitly disallowed them (e.g. "identity;q=1, gzip;q=0").
+func NegotiateContentEncoding(acceptEncoding string) string {
+	qValues := make(map[string]float64)
+
+	for _, encoding := range strings.Split(acceptEncoding, ",") {
+		// Split each encoding by semicolon to handle q-values
+		parts := strings.Split(encoding, ";")
+
+		name := strings.ToLower(strings.TrimSpace(parts[0]))
+		if name == "" {
+			continue
+		}
+
+		q := 1.0
+
+		for _, param := range parts[1:] {
+			kv := strings.SplitN(param, "=", 2)
+			if len(kv) != 2 || strings.ToLower(strings.TrimSpace(kv[0])) != "q" {
+				continue
+			}
+
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(kv[1]), 64)
+			if err != nil || parsed < 0 || parsed > 1 {
+				// Ignore the encoding rather than guessing what the client meant.
+				q = 0
+
+				continue
+			}
+
+			q = parsed
+		}
+
+		qValues[name] = q
+	}
+
+	// Identity is always acceptable, but only preferred over a compressed encoding if it's listed explicitly
+	// with a higher q-value.
+	best := ContentEncodingIdentity
+	bestQ := qValues[ContentEncodingIdentity]
+
+	for _, encoding := range SupportedContentEncodings {
+		q, exists := qValues[encoding]
+		if !exists {
+			// "*" matches any encoding that isn't listed explicitly.
+			q = qValues["*"]
+		}
+
+		if q > 0 && q >= bestQ {
+			best = encoding
+			bestQ = q
+		}
+	}
+
+	return best
+}
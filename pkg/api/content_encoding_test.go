@@ -0,0 +1,38 @@
+package api_test
+
+import (
+	"testing"
+
+	"github.com/ethpandaops/checkpointz/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateContentEncoding(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		expected       string
+	}{
+		{"Empty", "", api.ContentEncodingIdentity},
+		{"Gzip", "gzip", api.ContentEncodingGzip},
+		{"Browser", "gzip, deflate, br", api.ContentEncodingGzip},
+		{"Unsupported", "br", api.ContentEncodingIdentity},
+		{"Case insensitive", "GZIP", api.ContentEncodingGzip},
+		{"QValue preferred unsupported", "gzip;q=0.5, br;q=1.0", api.ContentEncodingGzip},
+		{"QValue identity preferred", "identity;q=1, gzip;q=0.5", api.ContentEncodingIdentity},
+		{"QValue gzip preferred", "identity;q=0.5, gzip;q=0.8", api.ContentEncodingGzip},
+		{"QValue gzip disallowed", "identity;q=1, gzip;q=0", api.ContentEncodingIdentity},
+		{"QValue with spaces", "gzip ; q=0.3", api.ContentEncodingGzip},
+		{"QValue invalid", "gzip;q=abc", api.ContentEncodingIdentity},
+		{"Wildcard", "*", api.ContentEncodingGzip},
+		{"Wildcard disallowed", "*;q=0", api.ContentEncodingIdentity},
+		{"Wildcard with gzip disallowed", "gzip;q=0, *;q=1", api.ContentEncodingIdentity},
+		{"Equal preference", "identity, gzip", api.ContentEncodingGzip},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, api.NegotiateContentEncoding(tt.acceptEncoding))
+		})
+	}
+}
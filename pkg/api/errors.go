@@ -0,0 +1,21 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ethpandaops/checkpointz/pkg/beacon"
+)
+
+// errorStatusCode returns the status code for errors that should always be surfaced with a specific status code,
+// regardless of the endpoint. Other errors keep the status code chosen by the handler.
+func errorStatusCode(err error, statusCode int) int {
+	switch {
+	case beacon.IsEpochBoundaryError(err):
+		return http.StatusBadRequest
+	case errors.Is(err, beacon.ErrBootstrapping):
+		return http.StatusServiceUnavailable
+	default:
+		return statusCode
+	}
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ethpandaops/checkpointz/pkg/beacon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorStatusCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{
+			name:     "other errors keep the handler's status code",
+			err:      errors.New("block not found"),
+			expected: http.StatusInternalServerError,
+		},
+		{
+			name:     "not an epoch boundary",
+			err:      &beacon.EpochBoundaryError{Slot: 33, NearestSlot: 32},
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "still bootstrapping",
+			err:      fmt.Errorf("genesis %w", beacon.ErrBootstrapping),
+			expected: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, errorStatusCode(test.err, http.StatusInternalServerError))
+		})
+	}
+}
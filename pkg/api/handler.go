@@ -29,10 +29,16 @@ type Handler struct {
 	publicURL     string
 	brandName     string
 	brandImageURL string
+	cacheControl  beacon.CacheControlConfig
+
+	configReloader ConfigReloader
 
 	metrics Metrics
 }
 
+// ConfigReloader reloads the config from its source and applies it, returning the names of the configured upstreams.
+type ConfigReloader func(ctx context.Context) ([]string, error)
+
 func NewHandler(log logrus.FieldLogger, beac beacon.FinalityProvider, config *beacon.Config) *Handler {
 	return &Handler{
 		log: log.WithField("module", "api"),
@@ -42,6 +48,7 @@ func NewHandler(log logrus.FieldLogger, beac beacon.FinalityProvider, config *be
 		publicURL:     config.Frontend.PublicURL,
 		brandName:     config.Frontend.BrandName,
 		brandImageURL: config.Frontend.BrandImageURL,
+		cacheControl:  config.CacheControl,
 
 		metrics: NewMetrics("http"),
 	}
@@ -70,11 +77,30 @@ func (h *Handler) Register(ctx context.Context, router *httprouter.Router) error
 	router.GET("/checkpointz/v1/status", h.wrappedHandler(h.handleCheckpointzStatus))
 	router.GET("/checkpointz/v1/beacon/slots", h.wrappedHandler(h.handleCheckpointzBeaconSlots))
 	router.GET("/checkpointz/v1/beacon/slots/:slot", h.wrappedHandler(h.handleCheckpointzBeaconSlot))
+	router.GET("/checkpointz/v1/beacon/genesis_validators_root", h.wrappedHandler(h.handleCheckpointzBeaconGenesisValidatorsRoot))
 	router.GET("/checkpointz/v1/ready", h.wrappedHandler(h.handleCheckpointzReady))
 
 	return nil
 }
 
+// SetConfigReloader enables the admin config reload route. Must be called before RegisterAdmin.
+func (h *Handler) SetConfigReloader(reloader ConfigReloader) {
+	h.configReloader = reloader
+}
+
+// RegisterAdmin registers the operator-only routes. These should never be exposed publicly.
+func (h *Handler) RegisterAdmin(ctx context.Context, router *httprouter.Router) error {
+	router.GET("/checkpointz/v1/admin/backfill", h.wrappedHandler(h.handleCheckpointzAdminBackfill))
+	router.POST("/checkpointz/v1/admin/backfill/pause", h.wrappedHandler(h.handleCheckpointzAdminBackfillPause))
+	router.POST("/checkpointz/v1/admin/backfill/resume", h.wrappedHandler(h.handleCheckpointzAdminBackfillResume))
+
+	if h.configReloader != nil {
+		router.POST("/checkpointz/v1/admin/config/reload", h.wrappedHandler(h.handleCheckpointzAdminConfigReload))
+	}
+
+	return nil
+}
+
 func deriveRegisteredPath(request *http.Request, ps httprouter.Params) string {
 	registeredPath := request.URL.Path
 	for _, param := range ps {
@@ -111,6 +137,8 @@ func (h *Handler) wrappedHandler(handler func(ctx context.Context, r *http.Reque
 
 		response, err = handler(ctx, r, p, contentType)
 		if err != nil {
+			response.StatusCode = errorStatusCode(err, response.StatusCode)
+
 			if writeErr := WriteErrorResponse(w, err.Error(), response.StatusCode); writeErr != nil {
 				h.log.WithError(writeErr).Error("Failed to write error response")
 			}
@@ -208,7 +236,12 @@ func (h *Handler) handleEthV2BeaconBlocks(ctx context.Context, r *http.Request,
 
 	switch blockID.Type() {
 	case eth.BlockIDRoot, eth.BlockIDGenesis, eth.BlockIDSlot:
-		rsp.SetCacheControl("public, s-max-age=6000")
+		slot, err := block.Slot()
+		if err != nil {
+			return NewInternalServerErrorResponse(nil), err
+		}
+
+		rsp.SetCacheControl(h.finalizedCacheControl(ctx, slot))
 	case eth.BlockIDFinalized:
 		// TODO(sam.calder-mason): This should be calculated using the Weak-Subjectivity period.
 		rsp.SetCacheControl("public, s-max-age=30")
@@ -258,12 +291,15 @@ func (h *Handler) handleEthV2DebugBeaconStates(ctx context.Context, r *http.Requ
 	})
 
 	switch id.Type() {
-	case eth.StateIDSlot:
-		rsp.SetCacheControl("public, s-max-age=6000")
+	case eth.StateIDSlot, eth.StateIDRoot:
+		slot, err := state.Slot()
+		if err != nil {
+			return NewInternalServerErrorResponse(nil), err
+		}
+
+		rsp.SetCacheControl(h.finalizedCacheControl(ctx, slot))
 	case eth.StateIDFinalized:
 		rsp.SetCacheControl("public, s-max-age=180")
-	case eth.StateIDRoot:
-		rsp.SetCacheControl("public, s-max-age=6000")
 	case eth.StateIDHead:
 		rsp.SetCacheControl("public, s-max-age=30")
 	}
@@ -475,6 +511,10 @@ func (h *Handler) handleCheckpointzReady(ctx context.Context, r *http.Request, p
 		return NewInternalServerErrorResponse(nil), errors.New("no finalized checkpoint")
 	}
 
+	if !status.Healthy {
+		return NewInternalServerErrorResponse(nil), errors.New("no healthy upstreams in sync with the wall clock")
+	}
+
 	rsp := NewSuccessResponse(ContentTypeResolvers{
 		ContentTypeJSON: func() ([]byte, error) {
 			return json.Marshal(`true`)
@@ -641,7 +681,13 @@ func (h *Handler) handleEthV1BeaconBlobSidecars(ctx context.Context, r *http.Req
 	})
 
 	switch id.Type() {
-	case eth.BlockIDFinalized, eth.BlockIDRoot:
+	case eth.BlockIDRoot:
+		if len(sidecars) > 0 && sidecars[0].SignedBlockHeader != nil && sidecars[0].SignedBlockHeader.Message != nil {
+			rsp.SetCacheControl(h.finalizedCacheControl(ctx, sidecars[0].SignedBlockHeader.Message.Slot))
+		} else {
+			rsp.SetCacheControl("public, s-max-age=6000")
+		}
+	case eth.BlockIDFinalized:
 		rsp.SetCacheControl("public, s-max-age=6000")
 	default:
 		rsp.SetCacheControl("public, s-max-age=15")
@@ -649,3 +695,114 @@ func (h *Handler) handleEthV1BeaconBlobSidecars(ctx context.Context, r *http.Req
 
 	return rsp, nil
 }
+
+func (h *Handler) handleCheckpointzBeaconGenesisValidatorsRoot(ctx context.Context, r *http.Request, p httprouter.Params, contentType ContentType) (*HTTPResponse, error) {
+	if err := ValidateContentType(contentType, []ContentType{ContentTypeJSON}); err != nil {
+		return NewUnsupportedMediaTypeResponse(nil), err
+	}
+
+	root, err := h.checkpointz.V1BeaconGenesisValidatorsRoot(ctx, checkpointz.NewGenesisValidatorsRootRequest())
+	if err != nil {
+		return NewInternalServerErrorResponse(nil), err
+	}
+
+	rsp := NewSuccessResponse(ContentTypeResolvers{
+		ContentTypeJSON: func() ([]byte, error) {
+			return json.Marshal(root)
+		},
+	})
+
+	// The genesis validators root never changes for a network.
+	rsp.SetCacheControl("public, s-max-age=6000")
+
+	return rsp, nil
+}
+
+func (h *Handler) handleCheckpointzAdminBackfill(ctx context.Context, r *http.Request, p httprouter.Params, contentType ContentType) (*HTTPResponse, error) {
+	if err := ValidateContentType(contentType, []ContentType{ContentTypeJSON}); err != nil {
+		return NewUnsupportedMediaTypeResponse(nil), err
+	}
+
+	backfill, err := h.checkpointz.V1Backfill(ctx, checkpointz.NewBackfillRequest())
+	if err != nil {
+		return NewInternalServerErrorResponse(nil), err
+	}
+
+	rsp := NewSuccessResponse(ContentTypeResolvers{
+		ContentTypeJSON: func() ([]byte, error) {
+			return json.Marshal(backfill)
+		},
+	})
+
+	rsp.SetCacheControl("no-store")
+
+	return rsp, nil
+}
+
+func (h *Handler) handleCheckpointzAdminBackfillPause(ctx context.Context, r *http.Request, p httprouter.Params, contentType ContentType) (*HTTPResponse, error) {
+	if err := ValidateContentType(contentType, []ContentType{ContentTypeJSON}); err != nil {
+		return NewUnsupportedMediaTypeResponse(nil), err
+	}
+
+	backfill, err := h.checkpointz.V1PauseBackfill(ctx, checkpointz.NewBackfillRequest())
+	if err != nil {
+		return NewInternalServerErrorResponse(nil), err
+	}
+
+	rsp := NewSuccessResponse(ContentTypeResolvers{
+		ContentTypeJSON: func() ([]byte, error) {
+			return json.Marshal(backfill)
+		},
+	})
+
+	rsp.SetCacheControl("no-store")
+
+	return rsp, nil
+}
+
+func (h *Handler) handleCheckpointzAdminBackfillResume(ctx context.Context, r *http.Request, p httprouter.Params, contentType ContentType) (*HTTPResponse, error) {
+	if err := ValidateContentType(contentType, []ContentType{ContentTypeJSON}); err != nil {
+		return NewUnsupportedMediaTypeResponse(nil), err
+	}
+
+	backfill, err := h.checkpointz.V1ResumeBackfill(ctx, checkpointz.NewBackfillRequest())
+	if err != nil {
+		return NewInternalServerErrorResponse(nil), err
+	}
+
+	rsp := NewSuccessResponse(ContentTypeResolvers{
+		ContentTypeJSON: func() ([]byte, error) {
+			return json.Marshal(backfill)
+		},
+	})
+
+	rsp.SetCacheControl("no-store")
+
+	return rsp, nil
+}
+
+func (h *Handler) handleCheckpointzAdminConfigReload(ctx context.Context, r *http.Request, p httprouter.Params, contentType ContentType) (*HTTPResponse, error) {
+	if err := ValidateContentType(contentType, []ContentType{ContentTypeJSON}); err != nil {
+		return NewUnsupportedMediaTypeResponse(nil), err
+	}
+
+	upstreams, err := h.configReloader(ctx)
+	if err != nil {
+		// The previous config is still in use.
+		return NewBadRequestResponse(nil), err
+	}
+
+	rsp := NewSuccessResponse(ContentTypeResolvers{
+		ContentTypeJSON: func() ([]byte, error) {
+			return json.Marshal(struct {
+				Upstreams []string `json:"upstreams"`
+			}{
+				Upstreams: upstreams,
+			})
+		},
+	})
+
+	rsp.SetCacheControl("no-store")
+
+	return rsp, nil
+}
@@ -0,0 +1,108 @@
+package beacon
+
+import (
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// BackfillStatus holds the progress of the historical backfill.
+type BackfillStatus struct {
+	// Paused is true if the backfill has been paused by an operator.
+	Paused bool `json:"paused"`
+	// Running is true if a backfill pass is currently in progress.
+	Running bool `json:"running"`
+	// CurrentSlot is the epoch boundary slot that was most recently processed.
+	CurrentSlot phase0.Slot `json:"current_slot"`
+	// TargetSlot is the oldest epoch boundary the backfill is working towards. The genesis block is always kept
+	// as well but isn't part of the progress unless it's one of the epoch boundaries.
+	TargetSlot phase0.Slot `json:"target_slot"`
+	// Completed is the amount of slots that have been processed in the current pass.
+	Completed int `json:"completed"`
+	// Total is the amount of slots in scope for the current pass.
+	Total int `json:"total"`
+	// Percentage is the progress of the current pass (0-100).
+	Percentage float64 `json:"percentage"`
+}
+
+// backfill tracks the progress of the historical backfill and whether it has been paused.
+type backfill struct {
+	mu sync.Mutex
+
+	paused      bool
+	running     bool
+	currentSlot phase0.Slot
+	targetSlot  phase0.Slot
+	completed   int
+	total       int
+}
+
+func newBackfill() *backfill {
+	return &backfill{}
+}
+
+func (b *backfill) Pause() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.paused = true
+}
+
+func (b *backfill) Resume() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.paused = false
+}
+
+func (b *backfill) Paused() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.paused
+}
+
+func (b *backfill) start(total int, target phase0.Slot) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.running = true
+	b.total = total
+	b.targetSlot = target
+	b.completed = 0
+}
+
+func (b *backfill) advance(slot phase0.Slot) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.currentSlot = slot
+	b.completed++
+}
+
+func (b *backfill) finish() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.running = false
+}
+
+func (b *backfill) Status() *BackfillStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := &BackfillStatus{
+		Paused:      b.paused,
+		Running:     b.running,
+		CurrentSlot: b.currentSlot,
+		TargetSlot:  b.targetSlot,
+		Completed:   b.completed,
+		Total:       b.total,
+	}
+
+	if b.total > 0 {
+		status.Percentage = float64(b.completed) / float64(b.total) * 100
+	}
+
+	return status
+}
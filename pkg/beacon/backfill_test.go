@@ -0,0 +1,52 @@
+package beacon
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackfillProgress(t *testing.T) {
+	b := newBackfill()
+
+	status := b.Status()
+	assert.False(t, status.Running)
+	assert.Equal(t, float64(0), status.Percentage)
+
+	b.start(4, phase0.Slot(96))
+	b.advance(phase0.Slot(192))
+	b.advance(phase0.Slot(160))
+
+	status = b.Status()
+	assert.True(t, status.Running)
+	assert.Equal(t, phase0.Slot(160), status.CurrentSlot)
+	assert.Equal(t, phase0.Slot(96), status.TargetSlot)
+	assert.Equal(t, 2, status.Completed)
+	assert.Equal(t, 4, status.Total)
+	assert.Equal(t, float64(50), status.Percentage)
+
+	b.finish()
+
+	assert.False(t, b.Status().Running)
+}
+
+func TestBackfillPauseResume(t *testing.T) {
+	b := newBackfill()
+
+	assert.False(t, b.Paused())
+
+	b.Pause()
+	assert.True(t, b.Paused())
+	assert.True(t, b.Status().Paused)
+
+	b.Resume()
+	assert.False(t, b.Paused())
+}
+
+func TestHistoricalEpochBoundaries(t *testing.T) {
+	assert.Equal(t, []phase0.Slot{288, 256, 224}, historicalEpochBoundaries(10, 32, 4))
+	assert.Equal(t, []phase0.Slot{32, 0}, historicalEpochBoundaries(2, 32, 4), "boundaries before genesis should be skipped")
+	assert.Equal(t, []phase0.Slot{}, historicalEpochBoundaries(0, 32, 4))
+	assert.Equal(t, []phase0.Slot{}, historicalEpochBoundaries(10, 32, 1))
+}
@@ -0,0 +1,62 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrBootstrapping is returned for data that is fetched from the upstreams once at startup (e.g. the chain spec and
+// genesis) while it hasn't been fetched yet.
+var ErrBootstrapping = errors.New("not yet available, still bootstrapping from upstreams")
+
+// startBootstrapLoop fetches the chain spec and genesis from the upstreams, retrying with an exponential backoff
+// until both are available.
+func (d *Default) startBootstrapLoop(ctx context.Context) error {
+	backoff := d.config.Bootstrap.MinBackoff.Duration
+
+	for attempt := 1; ; attempt++ {
+		err := d.bootstrap(ctx)
+		if err == nil {
+			d.log.WithField("attempts", attempt).Info("Bootstrapped chain spec and genesis from upstreams")
+
+			return nil
+		}
+
+		d.log.WithError(err).
+			WithField("attempt", attempt).
+			WithField("retry_in", backoff.String()).
+			Warn("Failed to bootstrap from upstreams, retrying")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff = nextBackoff(backoff, d.config.Bootstrap.MaxBackoff.Duration)
+	}
+}
+
+func (d *Default) bootstrap(ctx context.Context) error {
+	if err := d.checkBeaconSpec(ctx); err != nil {
+		return fmt.Errorf("failed to fetch chain spec: %w", err)
+	}
+
+	if err := d.checkGenesisTime(ctx); err != nil {
+		return fmt.Errorf("failed to fetch genesis: %w", err)
+	}
+
+	return nil
+}
+
+// nextBackoff doubles the backoff, capped at max.
+func nextBackoff(current, max time.Duration) time.Duration {
+	next := current * 2
+	if next > max || next <= 0 {
+		return max
+	}
+
+	return next
+}
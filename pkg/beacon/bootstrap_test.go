@@ -0,0 +1,15 @@
+package beacon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextBackoff(t *testing.T) {
+	assert.Equal(t, 2*time.Second, nextBackoff(time.Second, time.Minute))
+	assert.Equal(t, 64*time.Second, nextBackoff(32*time.Second, 64*time.Second))
+	assert.Equal(t, time.Minute, nextBackoff(32*time.Second, time.Minute))
+	assert.Equal(t, time.Minute, nextBackoff(time.Minute, time.Minute))
+}
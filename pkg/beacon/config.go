@@ -1,10 +1,15 @@
 package beacon
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/checkpointz/pkg/beacon/store"
+	"github.com/ethpandaops/checkpointz/pkg/human"
 )
 
 // Config holds configuration for running a FinalityProvider config
@@ -19,6 +24,23 @@ type Config struct {
 
 	// Cache holds configuration for the caches.
 	Frontend FrontendConfig `yaml:"frontend"`
+
+	// CacheControl holds configuration for the cache-control headers of finalized data.
+	CacheControl CacheControlConfig `yaml:"cache_control"`
+
+	// Bootstrap holds configuration for fetching the chain spec and genesis from the upstreams at startup.
+	Bootstrap BootstrapConfig `yaml:"bootstrap"`
+
+	// PinnedCheckpoint pins the checkpoint served as "finalized" in the format <root>:<epoch>. The serving
+	// checkpoint will not advance with the chain while set.
+	PinnedCheckpoint string `yaml:"pinned_checkpoint"`
+
+	// EpochBoundariesOnly restricts the servable blocks and states to those at epoch boundaries.
+	EpochBoundariesOnly bool `yaml:"epoch_boundaries_only" default:"false"`
+
+	// UpstreamDesyncSlots is how many slots an upstream's head can be ahead or behind the wall clock slot before
+	// it's considered out of sync. Disabled if 0.
+	UpstreamDesyncSlots uint64 `yaml:"upstream_desync_slots" default:"32"`
 }
 
 // Cache configuration holds configuration for the caches.
@@ -47,6 +69,28 @@ type FrontendConfig struct {
 	BrandImageURL string `yaml:"brand_image_url"`
 }
 
+// CacheControlConfig holds configuration for the cache-control headers of finalized data.
+type CacheControlConfig struct {
+	// Immutable marks finalized data older than the weak subjectivity period as immutable.
+	Immutable bool `yaml:"immutable" default:"true"`
+
+	// WeakSubjectivityPeriod is the age after which finalized data can no longer be reorged.
+	WeakSubjectivityPeriod human.Duration `yaml:"weak_subjectivity_period" default:"\"336h\""`
+
+	// StaleWhileRevalidate allows caches to serve finalized data that is newer than the weak subjectivity period
+	// while revalidating it in the background. Disabled if 0.
+	StaleWhileRevalidate human.Duration `yaml:"stale_while_revalidate" default:"\"1h\""`
+}
+
+// BootstrapConfig holds configuration for fetching the chain spec and genesis from the upstreams at startup.
+type BootstrapConfig struct {
+	// MinBackoff is the delay before the first retry. It doubles after every failed attempt.
+	MinBackoff human.Duration `yaml:"min_backoff" default:"\"1s\""`
+
+	// MaxBackoff is the maximum delay between retries.
+	MaxBackoff human.Duration `yaml:"max_backoff" default:"\"1m\""`
+}
+
 func (c *Config) Validate() error {
 	if c.HistoricalEpochCount < 1 {
 		return errors.New("historical_epoch_count must be at least 1")
@@ -64,9 +108,62 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("historical_epoch_count (%d) cannot be higher than 200", c.HistoricalEpochCount)
 	}
 
+	if err := c.CacheControl.Validate(); err != nil {
+		return fmt.Errorf("invalid cache_control config: %s", err)
+	}
+
+	if err := c.Bootstrap.Validate(); err != nil {
+		return fmt.Errorf("invalid bootstrap config: %s", err)
+	}
+
+	pinned, err := c.Pinned()
+	if err != nil {
+		return fmt.Errorf("invalid pinned_checkpoint: %s", err)
+	}
+
+	// The justified checkpoints of a pinned checkpoint are read from its state, which is only downloaded in full mode.
+	if pinned != nil && c.Mode != OperatingModeFull {
+		return fmt.Errorf("pinned_checkpoint requires %s mode", OperatingModeFull)
+	}
+
 	return nil
 }
 
+// Pinned returns the pinned checkpoint, or nil if no checkpoint has been pinned.
+func (c *Config) Pinned() (*phase0.Checkpoint, error) {
+	if c.PinnedCheckpoint == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(c.PinnedCheckpoint, ":")
+	if len(parts) != 2 {
+		return nil, errors.New("expected format <root>:<epoch>")
+	}
+
+	b, err := hex.DecodeString(strings.TrimPrefix(parts[0], "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid root: %s", err)
+	}
+
+	root := phase0.Root{}
+
+	if len(b) != len(root) {
+		return nil, fmt.Errorf("incorrect length %d for root", len(b))
+	}
+
+	copy(root[:], b)
+
+	epoch, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid epoch: %s", err)
+	}
+
+	return &phase0.Checkpoint{
+		Root:  root,
+		Epoch: phase0.Epoch(epoch),
+	}, nil
+}
+
 func (c *CacheConfig) Validate() error {
 	if err := c.Blocks.Validate(); err != nil {
 		return fmt.Errorf("invalid blocks config: %s", err)
@@ -86,3 +183,27 @@ func (c *CacheConfig) Validate() error {
 
 	return nil
 }
+
+func (c *CacheControlConfig) Validate() error {
+	if c.Immutable && c.WeakSubjectivityPeriod.Duration <= 0 {
+		return errors.New("weak_subjectivity_period must be positive when immutable is enabled")
+	}
+
+	if c.StaleWhileRevalidate.Duration < 0 {
+		return errors.New("stale_while_revalidate must not be negative")
+	}
+
+	return nil
+}
+
+func (c *BootstrapConfig) Validate() error {
+	if c.MinBackoff.Duration <= 0 {
+		return errors.New("min_backoff must be positive")
+	}
+
+	if c.MaxBackoff.Duration < c.MinBackoff.Duration {
+		return errors.New("max_backoff must not be less than min_backoff")
+	}
+
+	return nil
+}
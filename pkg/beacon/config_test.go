@@ -0,0 +1,112 @@
+package beacon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/creasty/defaults"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigPinned(t *testing.T) {
+	root := "0x4d611d5b93fdab69013a7f0a2f961caca0c853f87cfe9595fe50038163079360"
+
+	tests := []struct {
+		name     string
+		pinned   string
+		expected *phase0.Checkpoint
+		wantErr  bool
+	}{
+		{
+			name:   "not pinned",
+			pinned: "",
+		},
+		{
+			name:   "valid",
+			pinned: root + ":1024",
+			expected: &phase0.Checkpoint{
+				Root: phase0.Root{
+					0x4d, 0x61, 0x1d, 0x5b, 0x93, 0xfd, 0xab, 0x69, 0x01, 0x3a, 0x7f, 0x0a, 0x2f, 0x96, 0x1c, 0xac,
+					0xa0, 0xc8, 0x53, 0xf8, 0x7c, 0xfe, 0x95, 0x95, 0xfe, 0x50, 0x03, 0x81, 0x63, 0x07, 0x93, 0x60,
+				},
+				Epoch: 1024,
+			},
+		},
+		{
+			name:    "missing epoch",
+			pinned:  root,
+			wantErr: true,
+		},
+		{
+			name:    "invalid epoch",
+			pinned:  root + ":abc",
+			wantErr: true,
+		},
+		{
+			name:    "short root",
+			pinned:  "0x4d611d5b:1024",
+			wantErr: true,
+		},
+		{
+			name:    "invalid root",
+			pinned:  "0xzz:1024",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{PinnedCheckpoint: tt.pinned}
+
+			checkpoint, err := config.Pinned()
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, checkpoint)
+		})
+	}
+}
+
+func TestConfigPinnedRequiresFullMode(t *testing.T) {
+	config := &Config{}
+
+	require.NoError(t, defaults.Set(config))
+
+	config.PinnedCheckpoint = "0x4d611d5b93fdab69013a7f0a2f961caca0c853f87cfe9595fe50038163079360:1024"
+
+	config.Mode = OperatingModeLight
+	assert.Error(t, config.Validate())
+
+	config.Mode = OperatingModeFull
+	assert.NoError(t, config.Validate())
+}
+
+func TestCacheControlConfigDefaults(t *testing.T) {
+	config := &Config{}
+
+	require.NoError(t, defaults.Set(config))
+
+	assert.True(t, config.CacheControl.Immutable)
+	assert.Equal(t, 14*24*time.Hour, config.CacheControl.WeakSubjectivityPeriod.Duration)
+	assert.Equal(t, time.Hour, config.CacheControl.StaleWhileRevalidate.Duration)
+	assert.NoError(t, config.CacheControl.Validate())
+}
+
+func TestBootstrapConfigDefaults(t *testing.T) {
+	config := &Config{}
+
+	require.NoError(t, defaults.Set(config))
+
+	assert.Equal(t, time.Second, config.Bootstrap.MinBackoff.Duration)
+	assert.Equal(t, time.Minute, config.Bootstrap.MaxBackoff.Duration)
+	assert.NoError(t, config.Bootstrap.Validate())
+
+	config.Bootstrap.MaxBackoff.Duration = time.Millisecond
+	assert.Error(t, config.Bootstrap.Validate())
+}
@@ -0,0 +1,149 @@
+package beacon
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+)
+
+// Upstream resources, used to label upstream decode failures.
+const (
+	upstreamResourceBlock           = "block"
+	upstreamResourceState           = "state"
+	upstreamResourceBlobSidecars    = "blob_sidecars"
+	upstreamResourceDepositSnapshot = "deposit_snapshot"
+)
+
+// decodeFailureMessages are the errors returned by the beacon api client when an upstream response can't be decoded.
+var decodeFailureMessages = []string{
+	"failed to decode",
+	"failed to parse",
+	"failed to unmarshal",
+	"unhandled block version",
+	"unhandled content type",
+}
+
+// decodeError is returned when a response from an upstream couldn't be decoded.
+type decodeError struct {
+	node     string
+	resource string
+	id       string
+	err      error
+}
+
+func (e *decodeError) Error() string {
+	return fmt.Sprintf("failed to decode %s %s from upstream %s: %v", e.resource, e.id, e.node, e.err)
+}
+
+func (e *decodeError) Unwrap() error {
+	return e.err
+}
+
+// isDecodeFailure guesses whether an error returned by the beacon api client was caused by an undecodable response.
+// The client doesn't return typed errors, so this matches on the error message.
+func isDecodeFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := err.Error()
+
+	for _, m := range decodeFailureMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkDecodeFailure logs and counts the error if it was caused by an undecodable upstream response, returning it as a
+// decodeError so the request can be failed over to another upstream. Other errors are returned as-is.
+func (d *Default) checkDecodeFailure(err error, upstream *Node, resource, id, version string) error {
+	var decodeErr *decodeError
+	if errors.As(err, &decodeErr) {
+		return err
+	}
+
+	if !isDecodeFailure(err) {
+		return err
+	}
+
+	fields := logrus.Fields{
+		"node":     upstream.Config.Name,
+		"resource": resource,
+		"id":       id,
+	}
+
+	if version != "" {
+		fields["version"] = version
+	}
+
+	d.log.WithError(err).WithFields(fields).Warn("Failed to decode upstream response")
+
+	d.metrics.ObserveUpstreamDecodeFailure(upstream.Config.Name, resource)
+
+	return &decodeError{
+		node:     upstream.Config.Name,
+		resource: resource,
+		id:       id,
+		err:      err,
+	}
+}
+
+// forkName returns the name of the fork active at the given slot, or an empty string if it isn't known yet.
+func (d *Default) forkName(slot phase0.Slot) string {
+	sp, err := d.Spec()
+	if err != nil {
+		return ""
+	}
+
+	fork, err := sp.ForkEpochs.CurrentFork(slot, sp.SlotsPerEpoch)
+	if err != nil {
+		return ""
+	}
+
+	return fork.Name
+}
+
+// tryUpstreams calls fn with each upstream in turn until it succeeds. Only decode failures (as returned by
+// checkDecodeFailure) are failed over to the next upstream, any other error is returned immediately.
+func (d *Default) tryUpstreams(upstreams Nodes, fn func(upstream *Node) error) error {
+	if len(upstreams) == 0 {
+		return errors.New("no upstreams available")
+	}
+
+	var err error
+
+	for i, upstream := range upstreams {
+		err = d.useUpstream(upstream, fn)
+		if err == nil {
+			return nil
+		}
+
+		var decodeErr *decodeError
+		if !errors.As(err, &decodeErr) {
+			return err
+		}
+
+		if i < len(upstreams)-1 {
+			d.log.WithError(err).
+				WithField("node", upstream.Config.Name).
+				WithField("next_node", upstreams[i+1].Config.Name).
+				Warn("Failing over to another upstream after a decode failure")
+		}
+	}
+
+	return err
+}
+
+// useUpstream calls fn with the upstream, keeping it from being stopped while fn runs should it be removed.
+func (d *Default) useUpstream(upstream *Node, fn func(upstream *Node) error) error {
+	release := upstream.inFlight.Acquire()
+	defer release()
+
+	return fn(upstream)
+}
@@ -0,0 +1,89 @@
+package beacon
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethpandaops/checkpointz/pkg/beacon/node"
+	perrors "github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsDecodeFailure(t *testing.T) {
+	assert.False(t, isDecodeFailure(nil))
+	assert.False(t, isDecodeFailure(errors.New("connection refused")))
+	assert.True(t, isDecodeFailure(perrors.Wrap(errors.New("incorrect size"), "failed to decode capella signed beacon block")))
+	assert.True(t, isDecodeFailure(perrors.Wrap(errors.New("unexpected EOF"), "failed to parse JSON")))
+}
+
+func TestTryUpstreamsFailsOverOnDecodeFailure(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+
+	d := &Default{
+		log:     logger,
+		metrics: NewMetrics("test_decode_failover"),
+	}
+
+	upstreams := Nodes{
+		{Config: node.Config{Name: "broken"}},
+		{Config: node.Config{Name: "working"}},
+	}
+
+	called := []string{}
+
+	err := d.tryUpstreams(upstreams, func(upstream *Node) error {
+		called = append(called, upstream.Config.Name)
+
+		if upstream.Config.Name == "broken" {
+			return d.checkDecodeFailure(errors.New("failed to decode capella beacon state"), upstream, upstreamResourceState, "32", "capella")
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"broken", "working"}, called)
+	assert.Equal(t, float64(1), testutil.ToFloat64(d.metrics.upstreamDecodeFailures.WithLabelValues("broken", upstreamResourceState)))
+	assert.Equal(t, float64(0), testutil.ToFloat64(d.metrics.upstreamDecodeFailures.WithLabelValues("working", upstreamResourceState)))
+}
+
+func TestTryUpstreamsReturnsOtherErrors(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+
+	d := &Default{
+		log: logger,
+	}
+
+	upstreams := Nodes{
+		{Config: node.Config{Name: "a"}},
+		{Config: node.Config{Name: "b"}},
+	}
+
+	calls := 0
+
+	err := d.tryUpstreams(upstreams, func(upstream *Node) error {
+		calls++
+
+		return errors.New("not found")
+	})
+	assert.EqualError(t, err, "not found")
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+
+	// Only errors that went through checkDecodeFailure are failed over.
+	err = d.tryUpstreams(upstreams, func(upstream *Node) error {
+		calls++
+
+		return errors.New("failed to decode capella beacon state")
+	})
+	assert.EqualError(t, err, "failed to decode capella beacon state")
+	assert.Equal(t, 1, calls)
+
+	assert.Error(t, d.tryUpstreams(Nodes{}, func(upstream *Node) error {
+		return nil
+	}))
+}
@@ -12,14 +12,12 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/deneb"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/chuckpreslar/emission"
-	"github.com/ethpandaops/beacon/pkg/beacon"
 	"github.com/ethpandaops/beacon/pkg/beacon/api/types"
 	"github.com/ethpandaops/beacon/pkg/beacon/state"
 	"github.com/ethpandaops/checkpointz/pkg/beacon/checkpoints"
 	"github.com/ethpandaops/checkpointz/pkg/beacon/node"
 	"github.com/ethpandaops/checkpointz/pkg/beacon/store"
 	"github.com/ethpandaops/checkpointz/pkg/eth"
-	"github.com/ethpandaops/ethwallclock"
 	"github.com/go-co-op/gocron"
 	perrors "github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -28,16 +26,21 @@ import (
 type Default struct {
 	log logrus.FieldLogger
 
-	config      *Config
-	nodeConfigs []node.Config
-	nodes       Nodes
-	broker      *emission.Emitter
+	namespace string
+	config    *Config
+	nodes     Nodes
+	broker    *emission.Emitter
+
+	// nodesCtx is the context upstreams are started with. Set once the provider has started.
+	nodesCtx        context.Context
+	nodesMutex      sync.RWMutex
+	registeredNodes map[string]struct{}
 
 	head          *v1.Finality
 	servingBundle *v1.Finality
 
-	blocks           *store.Block
-	states           *store.BeaconState
+	blocks           BlockStorage
+	states           StateStorage
 	depositSnapshots *store.DepositSnapshot
 	blobSidecars     *store.BlobSidecar
 
@@ -45,7 +48,14 @@ type Default struct {
 	spec      *state.Spec
 	genesis   *v1.Genesis
 
+	// specRefresh tracks the last epoch the spec was refreshed in, as every upstream triggers a refresh.
+	specRefreshMutex   sync.Mutex
+	specRefreshed      bool
+	specRefreshedEpoch uint64
+
 	historicalSlotFailures map[phase0.Slot]int
+	backfill               *backfill
+	desync                 *upstreamDesync
 
 	servingMutex    sync.Mutex
 	historicalMutex sync.Mutex
@@ -67,20 +77,34 @@ const (
 )
 
 func NewDefaultProvider(namespace string, log logrus.FieldLogger, nodes []node.Config, config *Config) FinalityProvider {
+	return NewDefaultProviderWithStorage(namespace, log, nodes, config, NewMemoryStorage(log, config.Caches, namespace))
+}
+
+// NewDefaultProviderWithStorage returns a new Default provider that stores blocks and states in the given storage.
+func NewDefaultProviderWithStorage(namespace string, log logrus.FieldLogger, nodes []node.Config, config *Config, storage Storage) FinalityProvider {
+	registeredNodes := make(map[string]struct{}, len(nodes))
+	for _, n := range nodes {
+		registeredNodes[n.Name] = struct{}{}
+	}
+
 	return &Default{
-		nodeConfigs: nodes,
-		log:         log.WithField("module", "beacon/default"),
-		nodes:       NewNodesFromConfig(log, nodes, namespace),
-		config:      config,
+		namespace: namespace,
+		log:       log.WithField("module", "beacon/default"),
+		nodes:     NewNodesFromConfig(log, nodes, namespace),
+		config:    config,
+
+		registeredNodes: registeredNodes,
 
 		head:          &v1.Finality{},
 		servingBundle: &v1.Finality{},
 
 		historicalSlotFailures: make(map[phase0.Slot]int),
+		backfill:               newBackfill(),
+		desync:                 newUpstreamDesync(),
 
 		broker:           emission.NewEmitter(),
-		blocks:           store.NewBlock(log, config.Caches.Blocks, namespace),
-		states:           store.NewBeaconState(log, config.Caches.States, namespace),
+		blocks:           storage.Blocks(),
+		states:           storage.States(),
 		depositSnapshots: store.NewDepositSnapshot(log, config.Caches.DepositSnapshots, namespace),
 		blobSidecars:     store.NewBlobSidecar(log, config.Caches.BlobSidecars, namespace),
 
@@ -88,6 +112,7 @@ func NewDefaultProvider(namespace string, log logrus.FieldLogger, nodes []node.C
 		historicalMutex: sync.Mutex{},
 		majorityMutex:   sync.Mutex{},
 		specMutex:       sync.Mutex{},
+		nodesMutex:      sync.RWMutex{},
 
 		metrics: NewMetrics(namespace + "_beacon"),
 	}
@@ -98,99 +123,48 @@ func (d *Default) Start(ctx context.Context) error {
 
 	d.metrics.ObserveOperatingMode(d.OperatingMode())
 
-	if err := d.nodes.StartAll(ctx); err != nil {
-		return err
+	d.nodesMutex.Lock()
+
+	d.nodesCtx = ctx
+
+	for _, node := range d.nodes {
+		d.startNode(ctx, node)
 	}
 
+	d.nodesMutex.Unlock()
+
 	go func() {
 		for {
 			// Wait until we have a single healthy node.
-			nd, err := d.nodes.Healthy(ctx).NotSyncing(ctx).RandomNode(ctx)
-			if err != nil {
+			if _, err := d.upstreams().Healthy(ctx).NotSyncing(ctx).RandomNode(ctx); err != nil {
 				d.log.WithError(err).Error("Waiting for a healthy, non-syncing node before beginning..")
 				time.Sleep(time.Second * 5)
 
 				continue
 			}
 
-			nd.Beacon.Wallclock().OnEpochChanged(func(epoch ethwallclock.Epoch) {
-				// Refresh the spec on epoch change.
-				// This will intentionally use any node (not the one that triggered the event) to fetch the spec.
-				if err := d.refreshSpec(ctx); err != nil {
-					d.log.WithError(err).Error("Failed to refresh spec")
+			// Only start bootstrapping once an upstream is healthy, otherwise the early attempts are bound to fail
+			// and push the backoff towards its maximum.
+			go func() {
+				if err := d.startBootstrapLoop(ctx); err != nil && !errors.Is(err, context.Canceled) {
+					d.log.WithError(err).Error("Failed to bootstrap from upstreams")
 				}
-			})
+			}()
 
 			if err := d.startCrons(ctx); err != nil {
 				d.log.WithError(err).Fatal("Failed to start crons")
 			}
 
 			go func() {
-				if err := d.startGenesisLoop(ctx); err != nil {
+				if err := d.startGenesisLoop(ctx); err != nil && !errors.Is(err, context.Canceled) {
 					d.log.WithError(err).Fatal("Failed to start genesis loop")
 				}
 			}()
 
-			if err := d.fetchUpstreamRequirements(ctx); err != nil {
-				d.log.WithError(err).Error("Failed to fetch upstream requirements")
-			}
-
 			break
 		}
 	}()
 
-	// Subscribe to the nodes' finality updates.
-	for _, node := range d.nodes {
-		n := node
-
-		logCtx := d.log.WithFields(logrus.Fields{
-			"node":   n.Config.Name,
-			"reason": "serving_updater",
-		})
-
-		n.Beacon.OnFinalityCheckpointUpdated(ctx, func(ctx context.Context, event *beacon.FinalityCheckpointUpdated) error {
-			logCtx.WithFields(logrus.Fields{
-				"epoch": event.Finality.Finalized.Epoch,
-				"root":  fmt.Sprintf("%#x", event.Finality.Finalized.Root),
-			}).Info("Node has a new finalized checkpoint")
-
-			// Check if we have a new majority finality.
-			if err := d.checkFinality(ctx); err != nil {
-				logCtx.WithError(err).Error("Failed to check finality")
-
-				return err
-			}
-
-			if err := d.checkForNewServingCheckpoint(ctx); err != nil {
-				logCtx.WithError(err).Error("Failed to check for new serving checkpoint after finality checkpoint updated")
-
-				return err
-			}
-
-			return nil
-		})
-
-		n.Beacon.OnReady(ctx, func(ctx context.Context, _ *beacon.ReadyEvent) error {
-			n.Beacon.Wallclock().OnEpochChanged(func(epoch ethwallclock.Epoch) {
-				time.Sleep(time.Second * 5)
-
-				if _, err := node.Beacon.FetchFinality(ctx, "head"); err != nil {
-					logCtx.WithError(err).Error("Failed to fetch finality after epoch transition")
-				}
-
-				if err := d.checkFinality(ctx); err != nil {
-					logCtx.WithError(err).Error("Failed to check finality")
-				}
-
-				if err := d.checkForNewServingCheckpoint(ctx); err != nil {
-					logCtx.WithError(err).Error("Failed to check for new serving checkpoint after epoch change")
-				}
-			})
-
-			return nil
-		})
-	}
-
 	return nil
 }
 
@@ -205,16 +179,16 @@ func (d *Default) startCrons(ctx context.Context) error {
 		return err
 	}
 
-	if _, err := s.Every("10s").Do(func() {
-		if err := d.checkBeaconSpec(ctx); err != nil {
-			d.log.WithError(err).Error("Failed to check beacon chain spec")
+	if _, err := s.Every("30s").Do(func() {
+		if err := d.checkUpstreamsDesync(ctx); err != nil {
+			d.log.WithError(err).Debug("Failed to check upstreams for desync")
 		}
 	}); err != nil {
 		return err
 	}
 
 	if _, err := s.Every("3m").Do(func() {
-		for _, node := range d.nodes.Healthy(ctx) {
+		for _, node := range d.upstreams().Healthy(ctx) {
 			if _, err := node.Beacon.FetchFinality(ctx, "head"); err != nil {
 				d.log.WithError(err).Error("Failed to fetch finality when polling")
 			}
@@ -224,13 +198,13 @@ func (d *Default) startCrons(ctx context.Context) error {
 	}
 
 	go func() {
-		if err := d.startServingLoop(ctx); err != nil {
+		if err := d.startServingLoop(ctx); err != nil && !errors.Is(err, context.Canceled) {
 			d.log.WithError(err).Fatal("Failed to start serving loop")
 		}
 	}()
 
 	go func() {
-		if err := d.startHistoricalLoop(ctx); err != nil {
+		if err := d.startHistoricalLoop(ctx); err != nil && !errors.Is(err, context.Canceled) {
 			d.log.WithError(err).Fatal("Failed to start historical loop")
 		}
 	}()
@@ -240,14 +214,6 @@ func (d *Default) startCrons(ctx context.Context) error {
 	return nil
 }
 
-func (d *Default) fetchUpstreamRequirements(ctx context.Context) error {
-	if err := d.refreshSpec(ctx); err != nil {
-		return err
-	}
-
-	return nil
-}
-
 func (d *Default) StartAsync(ctx context.Context) {
 	go func() {
 		if err := d.Start(ctx); err != nil {
@@ -261,17 +227,9 @@ func (d *Default) startGenesisLoop(ctx context.Context) error {
 		d.log.WithError(err).Error("Failed to check for genesis bundle")
 	}
 
-	if err := d.checkGenesisTime(ctx); err != nil {
-		d.log.WithError(err).Error("Failed to check genesis time")
-	}
-
 	for {
 		select {
 		case <-time.After(time.Second * 15):
-			if err := d.checkGenesisTime(ctx); err != nil {
-				d.log.WithError(err).Error("Failed to check genesis time")
-			}
-
 			if err := d.checkGenesis(ctx); err != nil {
 				d.log.WithError(err).Error("Failed to check for genesis")
 			}
@@ -289,6 +247,10 @@ func (d *Default) startHistoricalLoop(ctx context.Context) error {
 				continue
 			}
 
+			if d.backfill.Paused() {
+				continue
+			}
+
 			if err := d.fetchHistoricalCheckpoints(ctx, d.head); err != nil {
 				d.log.WithError(err).Error("Failed to fetch historical checkpoints")
 			}
@@ -321,6 +283,16 @@ func (d *Default) checkForNewServingCheckpoint(ctx context.Context) error {
 	d.servingMutex.Lock()
 	defer d.servingMutex.Unlock()
 
+	pinned, err := d.config.Pinned()
+	if err != nil {
+		return err
+	}
+
+	// A pinned checkpoint is always served, regardless of the head.
+	if pinned != nil {
+		return d.checkPinnedServingCheckpoint(ctx, pinned)
+	}
+
 	// Don't bother checking if we don't know the head yet.
 	if d.head == nil {
 		return errors.New("head finality is unknown")
@@ -361,8 +333,44 @@ func (d *Default) checkForNewServingCheckpoint(ctx context.Context) error {
 	return nil
 }
 
+func (d *Default) checkPinnedServingCheckpoint(ctx context.Context, pinned *phase0.Checkpoint) error {
+	logCtx := d.log.WithFields(logrus.Fields{
+		"pinned_epoch": pinned.Epoch,
+		"pinned_root":  fmt.Sprintf("%#x", pinned.Root),
+	})
+
+	if d.servingBundle != nil && d.servingBundle.Finalized != nil && *d.servingBundle.Finalized == *pinned {
+		// Make sure the bundle hasn't been evicted from the cache.
+		if d.hasBundle(ctx, pinned.Root) {
+			return nil
+		}
+
+		logCtx.Info("Pinned serving bundle is no longer cached, downloading")
+	} else {
+		logCtx.Info("Downloading pinned serving bundle")
+	}
+
+	return d.downloadServingCheckpoint(ctx, &v1.Finality{
+		Finalized: pinned,
+	})
+}
+
+func (d *Default) hasBundle(ctx context.Context, root phase0.Root) bool {
+	if _, err := d.GetBlockByRoot(ctx, root); err != nil {
+		return false
+	}
+
+	if !d.shouldDownloadStates() {
+		return true
+	}
+
+	st, err := d.GetBeaconStateByRoot(ctx, root)
+
+	return err == nil && st != nil
+}
+
 func (d *Default) Healthy(ctx context.Context) (bool, error) {
-	if len(d.nodes.Healthy(ctx)) == 0 {
+	if len(d.inSync(ctx, d.upstreams().Healthy(ctx))) == 0 {
 		return false, nil
 	}
 
@@ -372,7 +380,7 @@ func (d *Default) Healthy(ctx context.Context) (bool, error) {
 func (d *Default) Peers(ctx context.Context) (types.Peers, error) {
 	peers := types.Peers{}
 
-	for _, node := range d.nodes {
+	for _, node := range d.upstreams() {
 		status := "connected"
 
 		if node.Beacon.Status().Syncing() || !node.Beacon.Status().Healthy() {
@@ -390,7 +398,7 @@ func (d *Default) Peers(ctx context.Context) (types.Peers, error) {
 }
 
 func (d *Default) Syncing(ctx context.Context) (*v1.SyncState, error) {
-	syncing := len(d.nodes.Healthy(ctx).Syncing(ctx)) == len(d.nodes.Healthy(ctx))
+	syncing := len(d.upstreams().Healthy(ctx).Syncing(ctx)) == len(d.upstreams().Healthy(ctx))
 
 	syncState := &v1.SyncState{
 		IsSyncing:    syncing,
@@ -411,7 +419,8 @@ func (d *Default) Syncing(ctx context.Context) (*v1.SyncState, error) {
 		syncState.HeadSlot = phase0.Slot(d.head.Finalized.Epoch) * sp.SlotsPerEpoch
 	}
 
-	if d.servingBundle != nil && d.servingBundle.Finalized != nil {
+	// A pinned serving checkpoint can be ahead of our view of the head.
+	if d.servingBundle != nil && d.servingBundle.Finalized != nil && phase0.Slot(d.servingBundle.Finalized.Epoch)*sp.SlotsPerEpoch <= syncState.HeadSlot {
 		syncState.SyncDistance = syncState.HeadSlot - phase0.Slot(d.servingBundle.Finalized.Epoch)*sp.SlotsPerEpoch
 	}
 
@@ -419,7 +428,66 @@ func (d *Default) Syncing(ctx context.Context) (*v1.SyncState, error) {
 }
 
 func (d *Default) Finalized(ctx context.Context) (*v1.Finality, error) {
-	return d.servingBundle, nil
+	bundle := d.servingBundle
+	if bundle == nil || bundle.Finalized == nil || (bundle.Justified != nil && bundle.PreviousJustified != nil) {
+		return bundle, nil
+	}
+
+	// A pinned checkpoint only knows its finalized checkpoint, so take the justified checkpoints from the
+	// pinned state's own view of finality.
+	state, err := d.GetBeaconStateByRoot(ctx, bundle.Finalized.Root)
+	if err != nil {
+		return nil, fmt.Errorf("pinned state is unavailable: %w", err)
+	}
+
+	previousJustified, justified, err := justifiedCheckpoints(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get justified checkpoints from the pinned state: %w", err)
+	}
+
+	return &v1.Finality{
+		Finalized:         bundle.Finalized,
+		Justified:         justified,
+		PreviousJustified: previousJustified,
+	}, nil
+}
+
+// justifiedCheckpoints returns the previous and current justified checkpoints of the state.
+func justifiedCheckpoints(state *spec.VersionedBeaconState) (previous, current *phase0.Checkpoint, err error) {
+	if state == nil {
+		return nil, nil, errors.New("state is nil")
+	}
+
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		if state.Phase0 != nil {
+			previous, current = state.Phase0.PreviousJustifiedCheckpoint, state.Phase0.CurrentJustifiedCheckpoint
+		}
+	case spec.DataVersionAltair:
+		if state.Altair != nil {
+			previous, current = state.Altair.PreviousJustifiedCheckpoint, state.Altair.CurrentJustifiedCheckpoint
+		}
+	case spec.DataVersionBellatrix:
+		if state.Bellatrix != nil {
+			previous, current = state.Bellatrix.PreviousJustifiedCheckpoint, state.Bellatrix.CurrentJustifiedCheckpoint
+		}
+	case spec.DataVersionCapella:
+		if state.Capella != nil {
+			previous, current = state.Capella.PreviousJustifiedCheckpoint, state.Capella.CurrentJustifiedCheckpoint
+		}
+	case spec.DataVersionDeneb:
+		if state.Deneb != nil {
+			previous, current = state.Deneb.PreviousJustifiedCheckpoint, state.Deneb.CurrentJustifiedCheckpoint
+		}
+	default:
+		return nil, nil, fmt.Errorf("unknown state version: %s", state.Version.String())
+	}
+
+	if previous == nil || current == nil {
+		return nil, nil, fmt.Errorf("%s state is missing justified checkpoints", state.Version.String())
+	}
+
+	return previous, current, nil
 }
 
 func (d *Default) Head(ctx context.Context) (*v1.Finality, error) {
@@ -428,7 +496,7 @@ func (d *Default) Head(ctx context.Context) (*v1.Finality, error) {
 
 func (d *Default) Genesis(ctx context.Context) (*v1.Genesis, error) {
 	if d.genesis == nil {
-		return nil, errors.New("genesis bundle not yet available")
+		return nil, fmt.Errorf("genesis %w", ErrBootstrapping)
 	}
 
 	return d.genesis, nil
@@ -446,7 +514,7 @@ func (d *Default) Spec() (*state.Spec, error) {
 	defer d.specMutex.Unlock()
 
 	if d.spec == nil {
-		return nil, errors.New("config spec not yet available")
+		return nil, fmt.Errorf("config spec %w", ErrBootstrapping)
 	}
 
 	copied := *d.spec
@@ -467,7 +535,12 @@ func (d *Default) checkFinality(ctx context.Context) error {
 	defer d.majorityMutex.Unlock()
 
 	aggFinality := []*v1.Finality{}
-	readyNodes := d.nodes.Ready(ctx)
+	readyNodes := d.upstreams().Ready(ctx)
+
+	// Only let upstreams that agree with the wall clock vote, unless none of them do.
+	if inSync := d.inSync(ctx, readyNodes); len(inSync) > 0 {
+		readyNodes = inSync
+	}
 
 	for _, node := range readyNodes {
 		finality, err := node.Beacon.Finality()
@@ -504,7 +577,7 @@ func (d *Default) checkFinality(ctx context.Context) error {
 func (d *Default) refreshSpec(ctx context.Context) error {
 	d.log.Debug("Fetching beacon spec")
 
-	upstream, err := d.nodes.Ready(ctx).DataProviders(ctx).RandomNode(ctx)
+	upstream, err := d.upstreams().Ready(ctx).DataProviders(ctx).RandomNode(ctx)
 	if err != nil {
 		return err
 	}
@@ -540,7 +613,7 @@ func (d *Default) checkGenesisTime(ctx context.Context) error {
 
 	d.log.Debug("Fetching genesis time")
 
-	upstream, err := d.nodes.Ready(ctx).DataProviders(ctx).RandomNode(ctx)
+	upstream, err := d.upstreams().Ready(ctx).DataProviders(ctx).RandomNode(ctx)
 	if err != nil {
 		return err
 	}
@@ -571,7 +644,11 @@ func (d *Default) publishFinalityCheckpointHeadUpdated(_ context.Context, checkp
 }
 
 func (d *Default) GetBlockBySlot(ctx context.Context, slot phase0.Slot) (*spec.VersionedSignedBeaconBlock, error) {
-	block, err := d.blocks.GetBySlot(slot)
+	if err := d.checkEpochBoundary(slot); err != nil {
+		return nil, err
+	}
+
+	block, err := d.blocks.GetBySlot(ctx, slot)
 	if err != nil {
 		return nil, err
 	}
@@ -584,7 +661,7 @@ func (d *Default) GetBlockBySlot(ctx context.Context, slot phase0.Slot) (*spec.V
 }
 
 func (d *Default) GetBlockByRoot(ctx context.Context, root phase0.Root) (*spec.VersionedSignedBeaconBlock, error) {
-	block, err := d.blocks.GetByRoot(root)
+	block, err := d.blocks.GetByRoot(ctx, root)
 	if err != nil {
 		return nil, err
 	}
@@ -593,11 +670,15 @@ func (d *Default) GetBlockByRoot(ctx context.Context, root phase0.Root) (*spec.V
 		return nil, errors.New("block not found")
 	}
 
+	if err := d.checkBlockEpochBoundary(block); err != nil {
+		return nil, err
+	}
+
 	return block, nil
 }
 
 func (d *Default) GetBlockByStateRoot(ctx context.Context, stateRoot phase0.Root) (*spec.VersionedSignedBeaconBlock, error) {
-	block, err := d.blocks.GetByStateRoot(stateRoot)
+	block, err := d.blocks.GetByStateRoot(ctx, stateRoot)
 	if err != nil {
 		return nil, err
 	}
@@ -606,10 +687,18 @@ func (d *Default) GetBlockByStateRoot(ctx context.Context, stateRoot phase0.Root
 		return nil, errors.New("block not found")
 	}
 
+	if err := d.checkBlockEpochBoundary(block); err != nil {
+		return nil, err
+	}
+
 	return block, nil
 }
 
 func (d *Default) GetBlobSidecarsBySlot(ctx context.Context, slot phase0.Slot) ([]*deneb.BlobSidecar, error) {
+	if err := d.checkEpochBoundary(slot); err != nil {
+		return nil, err
+	}
+
 	return d.blobSidecars.GetBySlot(slot)
 }
 
@@ -624,11 +713,11 @@ func (d *Default) GetBeaconStateBySlot(ctx context.Context, slot phase0.Slot) (*
 		return nil, err
 	}
 
-	return d.states.GetByStateRoot(stateRoot)
+	return d.states.GetByStateRoot(ctx, stateRoot)
 }
 
 func (d *Default) GetBeaconStateByStateRoot(ctx context.Context, stateRoot phase0.Root) (*spec.VersionedBeaconState, error) {
-	return d.states.GetByStateRoot(stateRoot)
+	return d.states.GetByStateRoot(ctx, stateRoot)
 }
 
 func (d *Default) GetBeaconStateByRoot(ctx context.Context, root phase0.Root) (*spec.VersionedBeaconState, error) {
@@ -642,10 +731,10 @@ func (d *Default) GetBeaconStateByRoot(ctx context.Context, root phase0.Root) (*
 		return nil, err
 	}
 
-	return d.states.GetByStateRoot(stateRoot)
+	return d.states.GetByStateRoot(ctx, stateRoot)
 }
 
-func (d *Default) storeBlock(_ context.Context, block *spec.VersionedSignedBeaconBlock) error {
+func (d *Default) storeBlock(ctx context.Context, block *spec.VersionedSignedBeaconBlock) error {
 	_, err := d.Spec()
 	if err != nil {
 		return err
@@ -664,7 +753,7 @@ func (d *Default) storeBlock(_ context.Context, block *spec.VersionedSignedBeaco
 		return err
 	}
 
-	exists, err := d.blocks.GetByRoot(root)
+	exists, err := d.blocks.GetByRoot(ctx, root)
 	if err == nil && exists != nil {
 		return nil
 	}
@@ -674,13 +763,18 @@ func (d *Default) storeBlock(_ context.Context, block *spec.VersionedSignedBeaco
 		return err
 	}
 
+	// Don't take up cache space with blocks that will never be served.
+	if err := d.checkEpochBoundary(slot); err != nil {
+		return err
+	}
+
 	expiresAt := time.Now().Add(FinalityHaltedServingPeriod)
 
 	if slot == phase0.Slot(0) {
 		expiresAt = time.Now().Add(999999 * time.Hour)
 	}
 
-	if err := d.blocks.Add(block, expiresAt); err != nil {
+	if err := d.blocks.Add(ctx, block, expiresAt); err != nil {
 		return err
 	}
 
@@ -690,13 +784,14 @@ func (d *Default) storeBlock(_ context.Context, block *spec.VersionedSignedBeaco
 func (d *Default) UpstreamsStatus(ctx context.Context) (map[string]*UpstreamStatus, error) {
 	rsp := make(map[string]*UpstreamStatus)
 
-	for _, node := range d.nodes {
+	for _, node := range d.upstreams() {
 		rsp[node.Config.Name] = &UpstreamStatus{
 			Name:    node.Config.Name,
 			Healthy: false,
 		}
 
 		rsp[node.Config.Name].Healthy = node.Beacon.Status().Healthy()
+		rsp[node.Config.Name].Desynced = d.desync.Desynced(node.Config.Name)
 
 		//nolint:gocritic // invalid
 		if spec, err := node.Beacon.Spec(); err == nil {
@@ -760,7 +855,7 @@ func (d *Default) GetEpochBySlot(ctx context.Context, slot phase0.Slot) (phase0.
 }
 
 func (d *Default) PeerCount(ctx context.Context) (uint64, error) {
-	return uint64(len(d.nodes.Healthy(ctx).NotSyncing(ctx))), nil
+	return uint64(len(d.upstreams().Healthy(ctx).NotSyncing(ctx))), nil
 }
 
 func (d *Default) GetSlotTime(ctx context.Context, slot phase0.Slot) (eth.SlotTime, error) {
@@ -778,6 +873,26 @@ func (d *Default) GetSlotTime(ctx context.Context, slot phase0.Slot) (eth.SlotTi
 	return eth.CalculateSlotTime(slot, d.genesis.GenesisTime, d.spec.SecondsPerSlot.AsDuration()), nil
 }
 
+func (d *Default) BackfillStatus(ctx context.Context) (*BackfillStatus, error) {
+	return d.backfill.Status(), nil
+}
+
+func (d *Default) PauseBackfill(ctx context.Context) error {
+	d.backfill.Pause()
+
+	d.log.Info("Pausing historical backfill")
+
+	return nil
+}
+
+func (d *Default) ResumeBackfill(ctx context.Context) error {
+	d.backfill.Resume()
+
+	d.log.Info("Resuming historical backfill")
+
+	return nil
+}
+
 func (d *Default) GetDepositSnapshot(ctx context.Context, epoch phase0.Epoch) (*types.DepositSnapshot, error) {
 	return d.depositSnapshots.GetByEpoch(epoch)
 }
@@ -0,0 +1,101 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBlockStorage struct {
+	BlockStorage
+
+	blocks map[phase0.Root]*spec.VersionedSignedBeaconBlock
+}
+
+func (f *fakeBlockStorage) GetByRoot(_ context.Context, root phase0.Root) (*spec.VersionedSignedBeaconBlock, error) {
+	block, ok := f.blocks[root]
+	if !ok {
+		return nil, errors.New("block not found")
+	}
+
+	return block, nil
+}
+
+func TestFinalizedTakesPinnedJustifiedCheckpointsFromState(t *testing.T) {
+	pinned := &phase0.Checkpoint{Epoch: 10, Root: phase0.Root{0x10}}
+	stateRoot := phase0.Root{0x11}
+
+	previousJustified := &phase0.Checkpoint{Epoch: 8, Root: phase0.Root{0x08}}
+	justified := &phase0.Checkpoint{Epoch: 9, Root: phase0.Root{0x09}}
+
+	states := &fakeStateStorage{
+		states: map[phase0.Root]*spec.VersionedBeaconState{},
+	}
+
+	d := &Default{
+		config: &Config{},
+		blocks: &fakeBlockStorage{
+			blocks: map[phase0.Root]*spec.VersionedSignedBeaconBlock{
+				pinned.Root: {
+					Version: spec.DataVersionPhase0,
+					Phase0: &phase0.SignedBeaconBlock{
+						Message: &phase0.BeaconBlock{Slot: 320, StateRoot: stateRoot},
+					},
+				},
+			},
+		},
+		states:        states,
+		servingBundle: &v1.Finality{Finalized: pinned},
+		head: &v1.Finality{
+			PreviousJustified: &phase0.Checkpoint{Epoch: 20, Root: phase0.Root{0x20}},
+			Justified:         &phase0.Checkpoint{Epoch: 21, Root: phase0.Root{0x21}},
+			Finalized:         &phase0.Checkpoint{Epoch: 19, Root: phase0.Root{0x19}},
+		},
+	}
+
+	_, err := d.Finalized(context.Background())
+	assert.Error(t, err, "the pinned state isn't available so the justified checkpoints are unknown")
+
+	states.states[stateRoot] = &spec.VersionedBeaconState{
+		Version: spec.DataVersionPhase0,
+		Phase0: &phase0.BeaconState{
+			PreviousJustifiedCheckpoint: previousJustified,
+			CurrentJustifiedCheckpoint:  justified,
+			FinalizedCheckpoint:         &phase0.Checkpoint{Epoch: 7, Root: phase0.Root{0x07}},
+		},
+	}
+
+	finality, err := d.Finalized(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &v1.Finality{
+		Finalized:         pinned,
+		Justified:         justified,
+		PreviousJustified: previousJustified,
+	}, finality, "the justified checkpoints should come from the pinned state, not the head")
+
+	// Unpinned serving bundles are returned as-is.
+	d.servingBundle = d.head
+
+	finality, err = d.Finalized(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, d.head, finality)
+}
+
+func TestValidateCheckpointBlock(t *testing.T) {
+	d := &Default{
+		spec: &state.Spec{SlotsPerEpoch: 32},
+	}
+
+	checkpoint := &phase0.Checkpoint{Epoch: 10, Root: phase0.Root{0x10}}
+
+	assert.NoError(t, d.validateCheckpointBlock(checkpoint, 320))
+	assert.Error(t, d.validateCheckpointBlock(checkpoint, 321), "not aligned to an epoch boundary")
+	assert.Error(t, d.validateCheckpointBlock(checkpoint, 352), "a block from a different epoch")
+}
@@ -0,0 +1,151 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/checkpointz/pkg/eth"
+	"github.com/sirupsen/logrus"
+)
+
+// upstreamDesync tracks how far each upstream's head is from the slot expected by the wall clock.
+type upstreamDesync struct {
+	mutex sync.RWMutex
+
+	// distances holds the last observed distance per upstream in slots. Positive if the upstream is ahead.
+	distances map[string]int64
+	desynced  map[string]struct{}
+}
+
+func newUpstreamDesync() *upstreamDesync {
+	return &upstreamDesync{
+		distances: make(map[string]int64),
+		desynced:  make(map[string]struct{}),
+	}
+}
+
+// Desynced returns true if the upstream's head was too far from the wall clock slot when last checked.
+func (u *upstreamDesync) Desynced(name string) bool {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+
+	_, exists := u.desynced[name]
+
+	return exists
+}
+
+// slotDistance returns how many slots the head slot is ahead (positive) or behind (negative) the expected slot.
+func slotDistance(headSlot, expectedSlot phase0.Slot) int64 {
+	if headSlot >= expectedSlot {
+		return int64(headSlot - expectedSlot)
+	}
+
+	return -int64(expectedSlot - headSlot)
+}
+
+// checkUpstreamsDesync compares the head slot of every upstream with the slot expected by the wall clock, flagging
+// upstreams whose notion of the chain is too far ahead or behind.
+func (d *Default) checkUpstreamsDesync(ctx context.Context) error {
+	if d.config.UpstreamDesyncSlots == 0 {
+		return nil
+	}
+
+	sp, err := d.Spec()
+	if err != nil {
+		return err
+	}
+
+	if d.genesis == nil {
+		return errors.New("genesis time is unknown")
+	}
+
+	expectedSlot := eth.CalculateSlotAtTime(time.Now(), d.genesis.GenesisTime, sp.SecondsPerSlot.AsDuration())
+
+	upstreams := d.upstreams()
+
+	d.desync.mutex.Lock()
+	defer d.desync.mutex.Unlock()
+
+	seen := make(map[string]struct{}, len(upstreams))
+
+	for _, upstream := range upstreams {
+		name := upstream.Config.Name
+
+		syncState := upstream.Beacon.Status().SyncState()
+		if syncState == nil {
+			continue
+		}
+
+		seen[name] = struct{}{}
+
+		distance := slotDistance(syncState.HeadSlot, expectedSlot)
+
+		d.desync.distances[name] = distance
+		d.metrics.ObserveUpstreamSlotDistance(name, distance)
+
+		_, wasDesynced := d.desync.desynced[name]
+
+		isDesynced := distance > int64(d.config.UpstreamDesyncSlots) || -distance > int64(d.config.UpstreamDesyncSlots)
+
+		d.metrics.ObserveUpstreamDesynced(name, isDesynced)
+
+		logCtx := d.log.WithFields(logrus.Fields{
+			"node":          name,
+			"head_slot":     syncState.HeadSlot,
+			"expected_slot": expectedSlot,
+			"distance":      distance,
+		})
+
+		switch {
+		case isDesynced && !wasDesynced:
+			d.desync.desynced[name] = struct{}{}
+
+			logCtx.Warn("Upstream is out of sync with the wall clock, other upstreams will be preferred")
+		case !isDesynced && wasDesynced:
+			delete(d.desync.desynced, name)
+
+			logCtx.Info("Upstream is back in sync with the wall clock")
+		}
+	}
+
+	// Forget upstreams that have since been removed.
+	for name := range d.desync.distances {
+		if _, exists := seen[name]; exists {
+			continue
+		}
+
+		delete(d.desync.distances, name)
+		delete(d.desync.desynced, name)
+		d.metrics.DeleteUpstreamDesync(name)
+	}
+
+	return nil
+}
+
+// inSync returns the upstreams that agree with the wall clock.
+func (d *Default) inSync(ctx context.Context, upstreams Nodes) Nodes {
+	return upstreams.Filter(ctx, func(node *Node) bool {
+		return !d.desync.Desynced(node.Config.Name)
+	})
+}
+
+// preferInSync returns the upstreams with the ones that agree with the wall clock first, keeping their order.
+func (d *Default) preferInSync(upstreams Nodes) Nodes {
+	inSync := Nodes{}
+	desynced := Nodes{}
+
+	for _, upstream := range upstreams {
+		if d.desync.Desynced(upstream.Config.Name) {
+			desynced = append(desynced, upstream)
+
+			continue
+		}
+
+		inSync = append(inSync, upstream)
+	}
+
+	return append(inSync, desynced...)
+}
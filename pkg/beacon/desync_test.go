@@ -0,0 +1,153 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/chuckpreslar/emission"
+	sbeacon "github.com/ethpandaops/beacon/pkg/beacon"
+	"github.com/ethpandaops/beacon/pkg/beacon/state"
+	"github.com/ethpandaops/checkpointz/pkg/beacon/node"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBeaconNode struct {
+	sbeacon.Node
+
+	status   *sbeacon.Status
+	finality *v1.Finality
+}
+
+func (f *fakeBeaconNode) Status() *sbeacon.Status {
+	return f.status
+}
+
+func (f *fakeBeaconNode) Finality() (*v1.Finality, error) {
+	return f.finality, nil
+}
+
+func newFakeNode(name string, headSlot phase0.Slot) *Node {
+	status := sbeacon.NewStatus(1, 1)
+	status.Health().RecordSuccess()
+	status.UpdateSyncState(&v1.SyncState{HeadSlot: headSlot})
+
+	return &Node{
+		Config: node.Config{Name: name},
+		Beacon: &fakeBeaconNode{status: status},
+	}
+}
+
+func TestSlotDistance(t *testing.T) {
+	assert.Equal(t, int64(0), slotDistance(100, 100))
+	assert.Equal(t, int64(5), slotDistance(105, 100))
+	assert.Equal(t, int64(-5), slotDistance(95, 100))
+}
+
+func TestCheckUpstreamsDesync(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+
+	secondsPerSlot := 12 * time.Second
+	expectedSlot := phase0.Slot(1000)
+
+	d := &Default{
+		log:     logger,
+		config:  &Config{UpstreamDesyncSlots: 32},
+		spec:    &state.Spec{SecondsPerSlot: state.StringerDuration(secondsPerSlot)},
+		genesis: &v1.Genesis{GenesisTime: time.Now().Add(-time.Duration(expectedSlot) * secondsPerSlot)},
+		nodes: Nodes{
+			newFakeNode("behind", expectedSlot-100),
+			newFakeNode("in_sync", expectedSlot-1),
+			newFakeNode("ahead", expectedSlot+100),
+		},
+		desync:  newUpstreamDesync(),
+		metrics: NewMetrics("test_upstream_desync"),
+	}
+
+	require.NoError(t, d.checkUpstreamsDesync(context.Background()))
+
+	assert.True(t, d.desync.Desynced("behind"))
+	assert.False(t, d.desync.Desynced("in_sync"))
+	assert.True(t, d.desync.Desynced("ahead"))
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(d.metrics.upstreamDesynced.WithLabelValues("behind")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(d.metrics.upstreamDesynced.WithLabelValues("in_sync")))
+	assert.InDelta(t, float64(-100), testutil.ToFloat64(d.metrics.upstreamSlotDistance.WithLabelValues("behind")), 1)
+
+	preferred := d.preferInSync(d.nodes)
+	require.Len(t, preferred, 3)
+	assert.Equal(t, "in_sync", preferred[0].Config.Name)
+	assert.Equal(t, "behind", preferred[1].Config.Name)
+	assert.Equal(t, "ahead", preferred[2].Config.Name)
+
+	// The upstream catches up.
+	d.nodes[0].Beacon.Status().UpdateSyncState(&v1.SyncState{HeadSlot: expectedSlot})
+
+	require.NoError(t, d.checkUpstreamsDesync(context.Background()))
+	assert.False(t, d.desync.Desynced("behind"))
+
+	// Removed upstreams are forgotten.
+	d.nodes = d.nodes[:2]
+
+	require.NoError(t, d.checkUpstreamsDesync(context.Background()))
+	assert.False(t, d.desync.Desynced("ahead"))
+	assert.NotContains(t, d.desync.distances, "ahead")
+}
+
+func TestDesyncedUpstreamsDontVoteOnFinality(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+
+	inSyncFinality := &v1.Finality{
+		PreviousJustified: &phase0.Checkpoint{Epoch: 8, Root: phase0.Root{0x08}},
+		Justified:         &phase0.Checkpoint{Epoch: 9, Root: phase0.Root{0x09}},
+		Finalized:         &phase0.Checkpoint{Epoch: 7, Root: phase0.Root{0x07}},
+	}
+
+	desyncedFinality := &v1.Finality{
+		PreviousJustified: &phase0.Checkpoint{Epoch: 2, Root: phase0.Root{0x02}},
+		Justified:         &phase0.Checkpoint{Epoch: 3, Root: phase0.Root{0x03}},
+		Finalized:         &phase0.Checkpoint{Epoch: 1, Root: phase0.Root{0x01}},
+	}
+
+	nodes := Nodes{
+		newFakeNode("in_sync", 0),
+		newFakeNode("desynced_a", 0),
+		newFakeNode("desynced_b", 0),
+	}
+
+	nodes[0].Beacon.(*fakeBeaconNode).finality = inSyncFinality
+	nodes[1].Beacon.(*fakeBeaconNode).finality = desyncedFinality
+	nodes[2].Beacon.(*fakeBeaconNode).finality = desyncedFinality
+
+	d := &Default{
+		log:     logger,
+		nodes:   nodes,
+		broker:  emission.NewEmitter(),
+		desync:  newUpstreamDesync(),
+		metrics: NewMetrics("test_desync_finality"),
+	}
+
+	healthy, err := d.Healthy(context.Background())
+	require.NoError(t, err)
+	assert.True(t, healthy)
+
+	d.desync.desynced["desynced_a"] = struct{}{}
+	d.desync.desynced["desynced_b"] = struct{}{}
+
+	require.NoError(t, d.checkFinality(context.Background()))
+	assert.Equal(t, inSyncFinality, d.head, "the desynced majority should be outvoted by the in-sync upstream")
+
+	d.desync.desynced["in_sync"] = struct{}{}
+
+	healthy, err = d.Healthy(context.Background())
+	require.NoError(t, err)
+	assert.False(t, healthy, "no upstream agrees with the wall clock")
+
+	require.NoError(t, d.checkFinality(context.Background()))
+	assert.Equal(t, desyncedFinality, d.head, "all upstreams vote when none of them are in sync")
+}
@@ -41,29 +41,22 @@ func (d *Default) downloadServingCheckpoint(ctx context.Context, checkpoint *v1.
 		WithField("fork_name", fork.Name).
 		Info("Downloading serving checkpoint")
 
-	upstream, err := d.nodes.
+	upstreams := d.preferInSync(d.upstreams().
 		Ready(ctx).
 		DataProviders(ctx).
 		PastFinalizedCheckpoint(ctx, checkpoint). // Ensure we attempt to fetch the bundle from a node that knows about the checkpoint.
-		RandomNode(ctx)
-	if err != nil {
-		return perrors.Wrap(err, "no data provider node available")
-	}
-
-	block, err := d.fetchBundle(ctx, checkpoint.Finalized.Root, upstream)
-	if err != nil {
-		return perrors.Wrap(err, "failed to fetch bundle")
+		Shuffle())
+	if len(upstreams) == 0 {
+		return errors.New("no data provider node available")
 	}
 
-	// Validate that everything is ok to serve.
-	// Lighthouse ref: https://lighthouse-book.sigmaprime.io/checkpoint-sync.html#alignment-requirements
-	blockSlot, err := block.Slot()
-	if err != nil {
-		return fmt.Errorf("failed to get slot from block: %w", err)
-	}
+	// The bundle's block is validated against the checkpoint before it's stored.
+	if err := d.tryUpstreams(upstreams, func(upstream *Node) error {
+		_, err := d.fetchBundle(ctx, checkpoint.Finalized, upstream)
 
-	if blockSlot%sp.SlotsPerEpoch != 0 {
-		return fmt.Errorf("block slot is not aligned from an epoch boundary: %d", blockSlot)
+		return err
+	}); err != nil {
+		return perrors.Wrap(err, "failed to fetch bundle")
 	}
 
 	d.servingBundle = checkpoint
@@ -86,11 +79,11 @@ func (d *Default) checkGenesis(ctx context.Context) error {
 	}
 
 	// No-Op if we already have the genesis state stored.
-	block, err := d.blocks.GetBySlot(phase0.Slot(0))
+	block, err := d.blocks.GetBySlot(ctx, phase0.Slot(0))
 	if err == nil && block != nil {
 		stateRoot, errr := block.StateRoot()
 		if errr == nil {
-			if st, er := d.states.GetByStateRoot(stateRoot); er == nil && st != nil {
+			if st, er := d.states.GetByStateRoot(ctx, stateRoot); er == nil && st != nil {
 				return nil
 			}
 		}
@@ -98,7 +91,7 @@ func (d *Default) checkGenesis(ctx context.Context) error {
 
 	d.log.Debug("Fetching genesis state")
 
-	readyNodes := d.nodes.Ready(ctx)
+	readyNodes := d.upstreams().Ready(ctx)
 	if len(readyNodes) == 0 {
 		return errors.New("no nodes ready")
 	}
@@ -123,13 +116,17 @@ func (d *Default) checkGenesis(ctx context.Context) error {
 		return err
 	}
 
-	upstream, err := d.nodes.Ready(ctx).DataProviders(ctx).RandomNode(ctx)
-	if err != nil {
-		return err
+	upstreams := d.preferInSync(d.upstreams().Ready(ctx).DataProviders(ctx).Shuffle())
+	if len(upstreams) == 0 {
+		return errors.New("no data provider node available")
 	}
 
 	// Fetch the bundle
-	if _, err := d.fetchBundle(ctx, genesisBlockRoot, upstream); err != nil {
+	if err := d.tryUpstreams(upstreams, func(upstream *Node) error {
+		_, err := d.fetchBundle(ctx, &phase0.Checkpoint{Epoch: 0, Root: genesisBlockRoot}, upstream)
+
+		return err
+	}); err != nil {
 		return err
 	}
 
@@ -154,64 +151,72 @@ func (d *Default) fetchHistoricalCheckpoints(ctx context.Context, checkpoint *v1
 	}
 
 	// Download the previous n epochs worth of epoch boundaries if they don't already exist
-	upstream, err := d.nodes.
+	upstreams := d.preferInSync(d.upstreams().
 		Ready(ctx).
 		DataProviders(ctx).
 		PastFinalizedCheckpoint(ctx, checkpoint).
-		RandomNode(ctx)
-	if err != nil {
+		Shuffle())
+	if len(upstreams) == 0 {
 		return errors.New("no data provider node available")
 	}
 
-	slotsInScope := make(map[phase0.Slot]struct{})
-
-	// We always care about the genesis slot.
-	slotsInScope[0] = struct{}{}
-
 	// historicalFailureLimit is the amount of times we'll try to download a block
 	// before we permanently give up.
 	historicalFailureLimit := 5
 
-	// Calculate the epoch boundaries we need to fetch
-	// We'll derive the current finalized slot and then work back in intervals of SLOTS_PER_EPOCH.
-	currentSlot := uint64(checkpoint.Finalized.Epoch) * uint64(sp.SlotsPerEpoch)
-	for i := uint64(1); i < uint64(d.config.HistoricalEpochCount); i++ {
-		slot := phase0.Slot(currentSlot - i*uint64(sp.SlotsPerEpoch))
+	boundaries := historicalEpochBoundaries(checkpoint.Finalized.Epoch, sp.SlotsPerEpoch, d.config.HistoricalEpochCount)
+
+	slotsInScope := make(map[phase0.Slot]struct{}, len(boundaries)+1)
+	for _, slot := range boundaries {
 		slotsInScope[slot] = struct{}{}
 	}
 
-	for slot := range slotsInScope {
-		failureCount, exists := d.historicalSlotFailures[slot]
-		if !exists {
-			d.historicalSlotFailures[slot] = 0
-		}
+	slots := boundaries
 
-		if failureCount >= historicalFailureLimit {
-			continue
-		}
+	// We always care about the genesis slot. It's fetched first and is only part of the reported progress
+	// if it's one of the epoch boundaries.
+	_, genesisIsBoundary := slotsInScope[0]
+	if !genesisIsBoundary {
+		slotsInScope[0] = struct{}{}
+		slots = append([]phase0.Slot{0}, boundaries...)
+	}
 
-		if _, err := d.blocks.GetBySlot(slot); err == nil {
-			continue
+	targetSlot := phase0.Slot(0)
+	if len(boundaries) > 0 {
+		targetSlot = boundaries[len(boundaries)-1]
+	}
+
+	d.backfill.start(len(boundaries), targetSlot)
+	defer d.backfill.finish()
+
+	for _, slot := range slots {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
 		}
 
-		if _, err := d.downloadBlock(ctx, slot, upstream); err != nil {
-			failureCount++
+		if d.backfill.Paused() {
+			d.log.Info("Historical backfill has been paused")
 
-			d.log.WithError(err).
-				WithField("slot", eth.SlotAsString(slot)).
-				WithField("failure_count", failureCount).
-				Error("Failed to download historical block")
+			return nil
 		}
 
-		if failureCount == historicalFailureLimit {
-			d.log.WithField("slot", eth.SlotAsString(slot)).
-				WithField("failure_count", failureCount).
-				Error("No longer attempting to download historical block - too many failures")
+		attempted := d.fetchHistoricalBlock(ctx, slot, upstreams, historicalFailureLimit)
+
+		if slot != 0 || genesisIsBoundary {
+			d.backfill.advance(slot)
 		}
 
-		d.historicalSlotFailures[slot] = failureCount
+		if !attempted {
+			continue
+		}
 
-		time.Sleep(50 * time.Millisecond)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(50 * time.Millisecond):
+		}
 	}
 
 	// Cleanup any banned slots that we don't care about anymore to prevent leaking memory.
@@ -224,6 +229,64 @@ func (d *Default) fetchHistoricalCheckpoints(ctx context.Context, checkpoint *v1
 	return nil
 }
 
+// historicalEpochBoundaries returns the epoch boundary slots before the finalized epoch that are kept, most recent first.
+func historicalEpochBoundaries(finalizedEpoch phase0.Epoch, slotsPerEpoch phase0.Slot, epochCount int) []phase0.Slot {
+	finalizedSlot := uint64(finalizedEpoch) * uint64(slotsPerEpoch)
+
+	boundaries := []phase0.Slot{}
+
+	for i := uint64(1); i < uint64(epochCount); i++ {
+		lookback := i * uint64(slotsPerEpoch)
+		if lookback > finalizedSlot {
+			break
+		}
+
+		boundaries = append(boundaries, phase0.Slot(finalizedSlot-lookback))
+	}
+
+	return boundaries
+}
+
+// fetchHistoricalBlock downloads the block at the slot unless it's already stored or has failed to download too
+// many times. Returns true if a download was attempted.
+func (d *Default) fetchHistoricalBlock(ctx context.Context, slot phase0.Slot, upstreams Nodes, failureLimit int) bool {
+	failureCount, exists := d.historicalSlotFailures[slot]
+	if !exists {
+		d.historicalSlotFailures[slot] = 0
+	}
+
+	if failureCount >= failureLimit {
+		return false
+	}
+
+	if _, err := d.blocks.GetBySlot(ctx, slot); err == nil {
+		return false
+	}
+
+	if err := d.tryUpstreams(upstreams, func(upstream *Node) error {
+		_, err := d.downloadBlock(ctx, slot, upstream)
+
+		return err
+	}); err != nil {
+		failureCount++
+
+		d.log.WithError(err).
+			WithField("slot", eth.SlotAsString(slot)).
+			WithField("failure_count", failureCount).
+			Error("Failed to download historical block")
+	}
+
+	if failureCount == failureLimit {
+		d.log.WithField("slot", eth.SlotAsString(slot)).
+			WithField("failure_count", failureCount).
+			Error("No longer attempting to download historical block - too many failures")
+	}
+
+	d.historicalSlotFailures[slot] = failureCount
+
+	return true
+}
+
 func (d *Default) downloadBlock(ctx context.Context, slot phase0.Slot, upstream *Node) (*spec.VersionedSignedBeaconBlock, error) {
 	// If we don't know genesis time yet, don't bother fetching blocks as
 	// we won't be able to calculate an expiry.
@@ -238,7 +301,7 @@ func (d *Default) downloadBlock(ctx context.Context, slot phase0.Slot, upstream
 	}
 
 	// Check if we already have the block.
-	bl, err := d.blocks.GetBySlot(slot)
+	bl, err := d.blocks.GetBySlot(ctx, slot)
 	if err == nil && bl != nil {
 		return bl, nil
 	}
@@ -246,7 +309,7 @@ func (d *Default) downloadBlock(ctx context.Context, slot phase0.Slot, upstream
 	// Download the block from our upstream.
 	block, err := upstream.Beacon.FetchBlock(ctx, eth.SlotAsString(slot))
 	if err != nil {
-		return nil, err
+		return nil, d.checkDecodeFailure(err, upstream, upstreamResourceBlock, eth.SlotAsString(slot), d.forkName(slot))
 	}
 
 	if block == nil {
@@ -279,15 +342,19 @@ func (d *Default) downloadBlock(ctx context.Context, slot phase0.Slot, upstream
 	return block, nil
 }
 
-func (d *Default) fetchBundle(ctx context.Context, root phase0.Root, upstream *Node) (*spec.VersionedSignedBeaconBlock, error) {
+// fetchBundle downloads and stores the block and state (plus deposit snapshot and blob sidecars) of the checkpoint.
+// The block is validated against the checkpoint before anything is stored.
+func (d *Default) fetchBundle(ctx context.Context, checkpoint *phase0.Checkpoint, upstream *Node) (*spec.VersionedSignedBeaconBlock, error) {
+	root := checkpoint.Root
+
 	d.log.Infof("Fetching bundle from node %s with root %#x", upstream.Config.Name, root)
 
-	block, err := d.blocks.GetByRoot(root)
+	block, err := d.blocks.GetByRoot(ctx, root)
 	if err != nil || block == nil {
 		// Download the block.
 		block, err = upstream.Beacon.FetchBlock(ctx, fmt.Sprintf("%#x", root))
 		if err != nil {
-			return nil, err
+			return nil, d.checkDecodeFailure(err, upstream, upstreamResourceBlock, fmt.Sprintf("%#x", root), "")
 		}
 
 		if block == nil {
@@ -320,6 +387,10 @@ func (d *Default) fetchBundle(ctx context.Context, root phase0.Root, upstream *N
 		WithField("state_root", fmt.Sprintf("%#x", stateRoot)).
 		Info("Fetched beacon block")
 
+	if err := d.validateCheckpointBlock(checkpoint, slot); err != nil {
+		return nil, err
+	}
+
 	err = d.storeBlock(ctx, block)
 	if err != nil {
 		return nil, fmt.Errorf("failed to store block: %w", err)
@@ -367,13 +438,15 @@ func (d *Default) fetchBundle(ctx context.Context, root phase0.Root, upstream *N
 
 func (d *Default) downloadAndStoreBeaconState(ctx context.Context, stateRoot phase0.Root, slot phase0.Slot, node *Node) error {
 	// If the state already exists, don't bother downloading it again.
-	existingState, err := d.states.GetByStateRoot(stateRoot)
+	existingState, err := d.states.GetByStateRoot(ctx, stateRoot)
 	if err == nil && existingState != nil {
 		return nil
 	}
 
 	beaconState, err := node.Beacon.FetchBeaconState(ctx, eth.SlotAsString(slot))
 	if err != nil {
+		err = d.checkDecodeFailure(err, node, upstreamResourceState, eth.SlotAsString(slot), d.forkName(slot))
+
 		return fmt.Errorf("failed to fetch beacon state: %w", err)
 	}
 
@@ -386,7 +459,7 @@ func (d *Default) downloadAndStoreBeaconState(ctx context.Context, stateRoot pha
 		expiresAt = time.Now().Add(999999 * time.Hour)
 	}
 
-	if err := d.states.Add(stateRoot, beaconState, expiresAt, slot); err != nil {
+	if err := d.states.Add(ctx, stateRoot, beaconState, expiresAt, slot); err != nil {
 		return fmt.Errorf("failed to store beacon state: %w", err)
 	}
 
@@ -402,7 +475,7 @@ func (d *Default) downloadAndStoreDepositSnapshot(ctx context.Context, epoch pha
 	// Download the deposit snapshot from our upstream.
 	depositSnapshot, err := node.Beacon.FetchDepositSnapshot(ctx)
 	if err != nil {
-		return err
+		return d.checkDecodeFailure(err, node, upstreamResourceDepositSnapshot, fmt.Sprintf("%d", epoch), "")
 	}
 
 	if depositSnapshot == nil {
@@ -437,7 +510,7 @@ func (d *Default) downloadAndStoreBlobSidecars(ctx context.Context, slot phase0.
 	// Download the blob sidecars from our upstream.
 	blobSidecars, err := node.Beacon.FetchBeaconBlockBlobs(ctx, eth.SlotAsString(slot))
 	if err != nil {
-		return err
+		return d.checkDecodeFailure(err, node, upstreamResourceBlobSidecars, eth.SlotAsString(slot), d.forkName(slot))
 	}
 
 	if blobSidecars == nil {
@@ -461,3 +534,23 @@ func (d *Default) downloadAndStoreBlobSidecars(ctx context.Context, slot phase0.
 
 	return nil
 }
+
+// validateCheckpointBlock checks that the block at the given slot can be served for the checkpoint. This guards
+// against e.g. a misconfigured pin serving a block from a different epoch.
+func (d *Default) validateCheckpointBlock(checkpoint *phase0.Checkpoint, slot phase0.Slot) error {
+	sp, err := d.Spec()
+	if err != nil {
+		return fmt.Errorf("failed to fetch spec: %w", err)
+	}
+
+	// Lighthouse ref: https://lighthouse-book.sigmaprime.io/checkpoint-sync.html#alignment-requirements
+	if slot%sp.SlotsPerEpoch != 0 {
+		return fmt.Errorf("block slot is not aligned from an epoch boundary: %d", slot)
+	}
+
+	if epoch := phase0.Epoch(slot / sp.SlotsPerEpoch); epoch != checkpoint.Epoch {
+		return fmt.Errorf("checkpoint epoch %d does not match block epoch %d", checkpoint.Epoch, epoch)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package beacon
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// EpochBoundaryError is returned for a slot that isn't an epoch boundary while only epoch boundaries are served.
+type EpochBoundaryError struct {
+	Slot        phase0.Slot
+	NearestSlot phase0.Slot
+}
+
+func (e *EpochBoundaryError) Error() string {
+	return fmt.Sprintf("slot %d is not an epoch boundary and only epoch boundary slots are served (nearest epoch boundary slot: %d)", e.Slot, e.NearestSlot)
+}
+
+// IsEpochBoundaryError returns true if the error was caused by requesting a slot that isn't an epoch boundary.
+func IsEpochBoundaryError(err error) bool {
+	var boundaryErr *EpochBoundaryError
+
+	return errors.As(err, &boundaryErr)
+}
+
+// NearestEpochBoundary returns the epoch boundary slot closest to the given slot, preferring the earlier boundary
+// when the slot is exactly between two.
+func NearestEpochBoundary(slot, slotsPerEpoch phase0.Slot) phase0.Slot {
+	if slotsPerEpoch == 0 {
+		return slot
+	}
+
+	offset := slot % slotsPerEpoch
+	if offset*2 <= slotsPerEpoch {
+		return slot - offset
+	}
+
+	return slot - offset + slotsPerEpoch
+}
+
+// checkEpochBoundary returns an EpochBoundaryError if only epoch boundaries are served and the slot isn't one.
+func (d *Default) checkEpochBoundary(slot phase0.Slot) error {
+	if !d.config.EpochBoundariesOnly {
+		return nil
+	}
+
+	sp, err := d.Spec()
+	if err != nil {
+		return err
+	}
+
+	if slot%sp.SlotsPerEpoch == 0 {
+		return nil
+	}
+
+	return &EpochBoundaryError{
+		Slot:        slot,
+		NearestSlot: NearestEpochBoundary(slot, sp.SlotsPerEpoch),
+	}
+}
+
+// checkBlockEpochBoundary is checkEpochBoundary for the slot of the given block.
+func (d *Default) checkBlockEpochBoundary(block *spec.VersionedSignedBeaconBlock) error {
+	if !d.config.EpochBoundariesOnly {
+		return nil
+	}
+
+	slot, err := block.Slot()
+	if err != nil {
+		return err
+	}
+
+	return d.checkEpochBoundary(slot)
+}
@@ -0,0 +1,48 @@
+package beacon
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNearestEpochBoundary(t *testing.T) {
+	tests := []struct {
+		slot     phase0.Slot
+		expected phase0.Slot
+	}{
+		{slot: 0, expected: 0},
+		{slot: 1, expected: 0},
+		{slot: 16, expected: 0},
+		{slot: 17, expected: 32},
+		{slot: 32, expected: 32},
+		{slot: 100, expected: 96},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, NearestEpochBoundary(tt.slot, 32), "slot %d", tt.slot)
+	}
+}
+
+func TestCheckEpochBoundary(t *testing.T) {
+	d := &Default{
+		config: &Config{},
+		spec:   &state.Spec{SlotsPerEpoch: 32},
+	}
+
+	assert.NoError(t, d.checkEpochBoundary(33), "all slots are servable by default")
+
+	d.config.EpochBoundariesOnly = true
+
+	assert.NoError(t, d.checkEpochBoundary(0))
+	assert.NoError(t, d.checkEpochBoundary(64))
+
+	err := d.checkEpochBoundary(70)
+	require.Error(t, err)
+	assert.True(t, IsEpochBoundaryError(err))
+	assert.Equal(t, &EpochBoundaryError{Slot: 70, NearestSlot: 64}, err)
+	assert.Contains(t, err.Error(), "nearest epoch boundary slot: 64")
+}
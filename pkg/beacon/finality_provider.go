@@ -9,6 +9,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/beacon/pkg/beacon/api/types"
 	"github.com/ethpandaops/beacon/pkg/beacon/state"
+	"github.com/ethpandaops/checkpointz/pkg/beacon/node"
 	"github.com/ethpandaops/checkpointz/pkg/eth"
 )
 
@@ -18,7 +19,7 @@ type FinalityProvider interface {
 	Start(ctx context.Context) error
 	// StartAsync starts the provider in a goroutine.
 	StartAsync(ctx context.Context)
-	// Healthy returns true if the provider is healthy.
+	// Healthy returns true if at least one upstream is healthy and in sync with the wall clock.
 	Healthy(ctx context.Context) (bool, error)
 	// Peers returns the peers the provider is connected to).
 	Peers(ctx context.Context) (types.Peers, error)
@@ -60,4 +61,12 @@ type FinalityProvider interface {
 	GetSlotTime(ctx context.Context, slot phase0.Slot) (eth.SlotTime, error)
 	// GetDepositSnapshot returns the deposit snapshot at the given epoch.
 	GetDepositSnapshot(ctx context.Context, epoch phase0.Epoch) (*types.DepositSnapshot, error)
+	// BackfillStatus returns the progress of the historical backfill.
+	BackfillStatus(ctx context.Context) (*BackfillStatus, error)
+	// PauseBackfill pauses the historical backfill.
+	PauseBackfill(ctx context.Context) error
+	// ResumeBackfill resumes a paused historical backfill.
+	ResumeBackfill(ctx context.Context) error
+	// UpdateUpstreams replaces the upstreams the provider uses without disrupting the cached data.
+	UpdateUpstreams(ctx context.Context, configs []node.Config) error
 }
@@ -9,6 +9,10 @@ type Metrics struct {
 	servingEpoch  prometheus.Gauge
 	headEpoch     prometheus.Gauge
 	operatingMode prometheus.GaugeVec
+
+	upstreamDecodeFailures *prometheus.CounterVec
+	upstreamSlotDistance   *prometheus.GaugeVec
+	upstreamDesynced       *prometheus.GaugeVec
 }
 
 func NewMetrics(namespace string) *Metrics {
@@ -29,11 +33,32 @@ func NewMetrics(namespace string) *Metrics {
 				Name:      "operating_mode",
 				Help:      "The current operating mode",
 			}, []string{"mode"}),
+		upstreamDecodeFailures: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "upstream_decode_failures_total",
+				Help:      "The amount of upstream responses that failed to decode",
+			}, []string{"node", "resource"}),
+		upstreamSlotDistance: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "upstream_slot_distance",
+				Help:      "The distance in slots between the upstream's head and the wall clock slot. Negative if the upstream is behind",
+			}, []string{"node"}),
+		upstreamDesynced: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "upstream_desynced",
+				Help:      "Whether the upstream's head is too far from the wall clock slot (1) or not (0)",
+			}, []string{"node"}),
 	}
 
 	prometheus.MustRegister(m.servingEpoch)
 	prometheus.MustRegister(m.headEpoch)
 	prometheus.MustRegister(m.operatingMode)
+	prometheus.MustRegister(m.upstreamDecodeFailures)
+	prometheus.MustRegister(m.upstreamSlotDistance)
+	prometheus.MustRegister(m.upstreamDesynced)
 
 	return m
 }
@@ -50,3 +75,25 @@ func (m *Metrics) ObserveOperatingMode(mode OperatingMode) {
 	m.operatingMode.Reset()
 	m.operatingMode.WithLabelValues(string(mode)).Set(1)
 }
+
+func (m *Metrics) ObserveUpstreamDecodeFailure(node, resource string) {
+	m.upstreamDecodeFailures.WithLabelValues(node, resource).Inc()
+}
+
+func (m *Metrics) ObserveUpstreamSlotDistance(node string, distance int64) {
+	m.upstreamSlotDistance.WithLabelValues(node).Set(float64(distance))
+}
+
+func (m *Metrics) ObserveUpstreamDesynced(node string, desynced bool) {
+	value := float64(0)
+	if desynced {
+		value = 1
+	}
+
+	m.upstreamDesynced.WithLabelValues(node).Set(value)
+}
+
+func (m *Metrics) DeleteUpstreamDesync(node string) {
+	m.upstreamSlotDistance.DeleteLabelValues(node)
+	m.upstreamDesynced.DeleteLabelValues(node)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"math/rand"
 	"strings"
+	"sync"
 	"time"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
@@ -16,48 +17,120 @@ import (
 type Node struct {
 	Config node.Config
 	Beacon sbeacon.Node
+
+	// cancel stops everything that was started for this node. Set when the node is started by the provider.
+	cancel context.CancelFunc
+
+	// inFlight counts the requests currently using the node, so a removed node is only stopped once they're done.
+	inFlight inFlight
 }
 
-type Nodes []*Node
+// inFlight counts in-flight requests.
+type inFlight struct {
+	mutex sync.Mutex
+	count int
+	// idle is closed once the count drops back to zero.
+	idle chan struct{}
+}
 
-func NewNodesFromConfig(log logrus.FieldLogger, configs []node.Config, namespace string) Nodes {
-	nodes := make(Nodes, len(configs))
+// Acquire marks a request as in-flight. The returned func must be called once the request has completed.
+func (f *inFlight) Acquire() func() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
 
-	for i, config := range configs {
-		sconfig := &sbeacon.Config{
-			Name:    config.Name,
-			Addr:    strings.TrimRight(config.Address, "/"),
-			Headers: config.Headers,
-		}
+	if f.count == 0 {
+		f.idle = make(chan struct{})
+	}
 
-		opts := *sbeacon.DefaultOptions()
+	f.count++
 
-		opts.HealthCheck.Interval.Duration = time.Second * 5
-		opts.HealthCheck.SuccessfulResponses = 2
+	var once sync.Once
 
-		snode := sbeacon.NewNode(log.WithField("upstream", config.Name), sconfig, namespace, opts)
+	return func() {
+		once.Do(f.release)
+	}
+}
 
-		snode.Options().BeaconSubscription.Enabled = true
+func (f *inFlight) release() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
 
-		opts.BeaconSubscription.Topics = sbeacon.EventTopics{
-			"finalized_checkpoint",
-		}
+	f.count--
 
-		nodes[i] = &Node{
-			Config: config,
-			Beacon: snode,
-		}
+	if f.count == 0 {
+		close(f.idle)
+	}
+}
+
+// Count returns the number of in-flight requests.
+func (f *inFlight) Count() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.count
+}
+
+// Wait blocks until there are no in-flight requests or the context is done.
+func (f *inFlight) Wait(ctx context.Context) error {
+	f.mutex.Lock()
+
+	if f.count == 0 {
+		f.mutex.Unlock()
+
+		return nil
+	}
+
+	idle := f.idle
+
+	f.mutex.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type Nodes []*Node
+
+func NewNodesFromConfig(log logrus.FieldLogger, configs []node.Config, namespace string) Nodes {
+	nodes := make(Nodes, len(configs))
+
+	for i, config := range configs {
+		nodes[i] = NewNodeFromConfig(log, config, namespace, true)
 	}
 
 	return nodes
 }
 
-func (n Nodes) StartAll(ctx context.Context) error {
-	for _, node := range n {
-		node.Beacon.StartAsync(ctx)
+// NewNodeFromConfig creates a new node from config. Prometheus metrics can only be registered once per node name
+// for the lifetime of the process, so metrics should be disabled when re-creating a node with a previously used name.
+func NewNodeFromConfig(log logrus.FieldLogger, config node.Config, namespace string, metrics bool) *Node {
+	sconfig := &sbeacon.Config{
+		Name:    config.Name,
+		Addr:    strings.TrimRight(config.Address, "/"),
+		Headers: config.Headers,
+	}
+
+	opts := *sbeacon.DefaultOptions()
+
+	opts.HealthCheck.Interval.Duration = time.Second * 5
+	opts.HealthCheck.SuccessfulResponses = 2
+	opts.PrometheusMetrics = metrics
+
+	snode := sbeacon.NewNode(log.WithField("upstream", config.Name), sconfig, namespace, opts)
+
+	snode.Options().BeaconSubscription.Enabled = true
+
+	opts.BeaconSubscription.Topics = sbeacon.EventTopics{
+		"finalized_checkpoint",
 	}
 
-	return nil
+	return &Node{
+		Config: config,
+		Beacon: snode,
+	}
 }
 
 func (n Nodes) DataProviders(ctx context.Context) Nodes {
@@ -133,6 +206,19 @@ func (n Nodes) RandomNode(ctx context.Context) (*Node, error) {
 	return nodes[rand.Intn(len(nodes))], nil
 }
 
+// Shuffle returns a copy of the nodes in a random order.
+func (n Nodes) Shuffle() Nodes {
+	nodes := make(Nodes, len(n))
+	copy(nodes, n)
+
+	//nolint:gosec // not critical to worry about/will probably be replaced.
+	rand.Shuffle(len(nodes), func(i, j int) {
+		nodes[i], nodes[j] = nodes[j], nodes[i]
+	})
+
+	return nodes
+}
+
 func (n Nodes) Filter(ctx context.Context, f func(*Node) bool) Nodes {
 	nodes := []*Node{}
 
@@ -9,4 +9,5 @@ type UpstreamStatus struct {
 	Healthy     bool         `json:"healthy"`
 	Finality    *v1.Finality `json:"finality"`
 	NetworkName string       `json:"network_name,omitempty"`
+	Desynced    bool         `json:"desynced"`
 }
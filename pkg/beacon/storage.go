@@ -0,0 +1,114 @@
+package beacon
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/checkpointz/pkg/beacon/store"
+	"github.com/sirupsen/logrus"
+)
+
+// Storage is the backend used to store downloaded artifacts. Implementations can share a common store
+// (e.g. an object store) between multiple checkpointz instances.
+type Storage interface {
+	// Blocks returns the block storage.
+	Blocks() BlockStorage
+	// States returns the beacon state storage.
+	States() StateStorage
+}
+
+// BlockStorage stores beacon blocks.
+type BlockStorage interface {
+	// Add stores the block until it expires. The genesis block should never expire.
+	Add(ctx context.Context, block *spec.VersionedSignedBeaconBlock, expiresAt time.Time) error
+	// GetByRoot returns the block with the given block root.
+	GetByRoot(ctx context.Context, root phase0.Root) (*spec.VersionedSignedBeaconBlock, error)
+	// GetByStateRoot returns the block with the given state root.
+	GetByStateRoot(ctx context.Context, stateRoot phase0.Root) (*spec.VersionedSignedBeaconBlock, error)
+	// GetBySlot returns the block at the given slot.
+	GetBySlot(ctx context.Context, slot phase0.Slot) (*spec.VersionedSignedBeaconBlock, error)
+	// List returns the block roots of all the stored blocks.
+	List(ctx context.Context) ([]phase0.Root, error)
+}
+
+// StateStorage stores beacon states.
+type StateStorage interface {
+	// Add stores the state until it expires. The genesis state should never expire.
+	Add(ctx context.Context, stateRoot phase0.Root, state *spec.VersionedBeaconState, expiresAt time.Time, slot phase0.Slot) error
+	// GetByStateRoot returns the state with the given state root.
+	GetByStateRoot(ctx context.Context, stateRoot phase0.Root) (*spec.VersionedBeaconState, error)
+	// List returns the state roots of all the stored states.
+	List(ctx context.Context) ([]phase0.Root, error)
+}
+
+// MemoryStorage is the default Storage. It keeps everything in memory, bounded by the cache config.
+type MemoryStorage struct {
+	blocks *memoryBlocks
+	states *memoryStates
+}
+
+var _ Storage = (*MemoryStorage)(nil)
+
+// NewMemoryStorage returns a new in-memory Storage.
+func NewMemoryStorage(log logrus.FieldLogger, config CacheConfig, namespace string) *MemoryStorage {
+	return &MemoryStorage{
+		blocks: &memoryBlocks{store: store.NewBlock(log, config.Blocks, namespace)},
+		states: &memoryStates{store: store.NewBeaconState(log, config.States, namespace)},
+	}
+}
+
+func (m *MemoryStorage) Blocks() BlockStorage {
+	return m.blocks
+}
+
+func (m *MemoryStorage) States() StateStorage {
+	return m.states
+}
+
+// memoryBlocks adapts the in-memory block store to BlockStorage. The context is unused as nothing blocks.
+type memoryBlocks struct {
+	store *store.Block
+}
+
+var _ BlockStorage = (*memoryBlocks)(nil)
+
+func (m *memoryBlocks) Add(_ context.Context, block *spec.VersionedSignedBeaconBlock, expiresAt time.Time) error {
+	return m.store.Add(block, expiresAt)
+}
+
+func (m *memoryBlocks) GetByRoot(_ context.Context, root phase0.Root) (*spec.VersionedSignedBeaconBlock, error) {
+	return m.store.GetByRoot(root)
+}
+
+func (m *memoryBlocks) GetByStateRoot(_ context.Context, stateRoot phase0.Root) (*spec.VersionedSignedBeaconBlock, error) {
+	return m.store.GetByStateRoot(stateRoot)
+}
+
+func (m *memoryBlocks) GetBySlot(_ context.Context, slot phase0.Slot) (*spec.VersionedSignedBeaconBlock, error) {
+	return m.store.GetBySlot(slot)
+}
+
+func (m *memoryBlocks) List(_ context.Context) ([]phase0.Root, error) {
+	return m.store.List()
+}
+
+// memoryStates adapts the in-memory beacon state store to StateStorage.
+type memoryStates struct {
+	store *store.BeaconState
+}
+
+var _ StateStorage = (*memoryStates)(nil)
+
+func (m *memoryStates) Add(_ context.Context, stateRoot phase0.Root, state *spec.VersionedBeaconState, expiresAt time.Time, slot phase0.Slot) error {
+	return m.store.Add(stateRoot, state, expiresAt, slot)
+}
+
+func (m *memoryStates) GetByStateRoot(_ context.Context, stateRoot phase0.Root) (*spec.VersionedBeaconState, error) {
+	return m.store.GetByStateRoot(stateRoot)
+}
+
+func (m *memoryStates) List(_ context.Context) ([]phase0.Root, error) {
+	return m.store.List()
+}
@@ -0,0 +1,119 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/checkpointz/pkg/beacon/store"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ctxKey struct{}
+
+type fakeStorage struct {
+	states *fakeStateStorage
+}
+
+func (f *fakeStorage) Blocks() BlockStorage {
+	return nil
+}
+
+func (f *fakeStorage) States() StateStorage {
+	return f.states
+}
+
+type fakeStateStorage struct {
+	ctx    context.Context
+	states map[phase0.Root]*spec.VersionedBeaconState
+}
+
+func (f *fakeStateStorage) Add(ctx context.Context, stateRoot phase0.Root, state *spec.VersionedBeaconState, _ time.Time, _ phase0.Slot) error {
+	f.ctx = ctx
+	f.states[stateRoot] = state
+
+	return nil
+}
+
+func (f *fakeStateStorage) GetByStateRoot(ctx context.Context, stateRoot phase0.Root) (*spec.VersionedBeaconState, error) {
+	f.ctx = ctx
+
+	state, ok := f.states[stateRoot]
+	if !ok {
+		return nil, errors.New("state not found")
+	}
+
+	return state, nil
+}
+
+func (f *fakeStateStorage) List(ctx context.Context) ([]phase0.Root, error) {
+	f.ctx = ctx
+
+	roots := make([]phase0.Root, 0, len(f.states))
+	for root := range f.states {
+		roots = append(roots, root)
+	}
+
+	return roots, nil
+}
+
+func TestDefaultProviderUsesGivenStorage(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+
+	stateRoot := phase0.Root{0x01}
+	state := &spec.VersionedBeaconState{Version: spec.DataVersionPhase0}
+
+	storage := &fakeStorage{
+		states: &fakeStateStorage{
+			states: map[phase0.Root]*spec.VersionedBeaconState{stateRoot: state},
+		},
+	}
+
+	provider := NewDefaultProviderWithStorage("test_storage", logger, nil, &Config{}, storage)
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "request")
+
+	got, err := provider.GetBeaconStateByStateRoot(ctx, stateRoot)
+	require.NoError(t, err)
+	assert.Same(t, state, got)
+	assert.Equal(t, "request", storage.states.ctx.Value(ctxKey{}), "the request context should reach the storage")
+
+	_, err = provider.GetBeaconStateByStateRoot(ctx, phase0.Root{0x02})
+	assert.Error(t, err)
+}
+
+func TestMemoryStorageList(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+
+	storage := NewMemoryStorage(logger, CacheConfig{
+		Blocks: store.Config{MaxItems: 10},
+		States: store.Config{MaxItems: 10},
+	}, "test_memory_storage_list")
+
+	ctx := context.Background()
+
+	roots, err := storage.States().List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, roots)
+
+	expiresAt := time.Now().Add(10 * time.Minute)
+
+	a := phase0.Root{0x01}
+	b := phase0.Root{0x02}
+
+	require.NoError(t, storage.States().Add(ctx, a, &spec.VersionedBeaconState{Version: spec.DataVersionPhase0}, expiresAt, 0))
+	require.NoError(t, storage.States().Add(ctx, b, &spec.VersionedBeaconState{Version: spec.DataVersionPhase0}, expiresAt, 32))
+
+	roots, err = storage.States().List(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []phase0.Root{a, b}, roots)
+
+	roots, err = storage.Blocks().List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, roots)
+}
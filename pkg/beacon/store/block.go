@@ -141,6 +141,11 @@ func (c *Block) GetBySlot(slot phase0.Slot) (*spec.VersionedSignedBeaconBlock, e
 	return c.GetByRoot(root)
 }
 
+// List returns the roots of all the stored blocks.
+func (c *Block) List() ([]phase0.Root, error) {
+	return parseRoots(c.store.Keys())
+}
+
 func (c *Block) parseBlock(data interface{}) (*spec.VersionedSignedBeaconBlock, error) {
 	block, ok := data.(*spec.VersionedSignedBeaconBlock)
 	if !ok {
@@ -158,3 +163,18 @@ func (c *Block) parseRoot(data interface{}) (phase0.Root, error) {
 
 	return root, nil
 }
+
+func parseRoots(keys []string) ([]phase0.Root, error) {
+	roots := make([]phase0.Root, 0, len(keys))
+
+	for _, key := range keys {
+		root, err := eth.RootFromString(key)
+		if err != nil {
+			return nil, err
+		}
+
+		roots = append(roots, root)
+	}
+
+	return roots, nil
+}
@@ -58,6 +58,11 @@ func (c *BeaconState) GetByStateRoot(stateRoot phase0.Root) (*spec.VersionedBeac
 	return c.parseState(data)
 }
 
+// List returns the state roots of all the stored states.
+func (c *BeaconState) List() ([]phase0.Root, error) {
+	return parseRoots(c.store.Keys())
+}
+
 func (c *BeaconState) parseState(data interface{}) (*spec.VersionedBeaconState, error) {
 	state, ok := data.(*spec.VersionedBeaconState)
 	if !ok {
@@ -0,0 +1,33 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBeaconStateList(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	stateStore := NewBeaconState(logger, Config{MaxItems: 10}, "test_state_list")
+
+	roots, err := stateStore.List()
+	require.NoError(t, err)
+	assert.Empty(t, roots)
+
+	expiresAt := time.Now().Add(10 * time.Minute)
+
+	a := phase0.Root{0x01}
+	b := phase0.Root{0x02}
+
+	require.NoError(t, stateStore.Add(a, &spec.VersionedBeaconState{Version: spec.DataVersionPhase0}, expiresAt, 0))
+	require.NoError(t, stateStore.Add(b, &spec.VersionedBeaconState{Version: spec.DataVersionPhase0}, expiresAt, 32))
+
+	roots, err = stateStore.List()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []phase0.Root{a, b}, roots)
+}
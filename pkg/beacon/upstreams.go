@@ -0,0 +1,246 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/ethpandaops/beacon/pkg/beacon"
+	"github.com/ethpandaops/checkpointz/pkg/beacon/node"
+	"github.com/ethpandaops/ethwallclock"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// NodeDrainTimeout is the longest a removed upstream is kept running while waiting for its in-flight requests
+	// to complete. Downloading a full beacon state can take minutes.
+	NodeDrainTimeout = 10 * time.Minute
+)
+
+// upstreams returns the current set of upstreams. The returned slice is never modified
+// once published, so it is safe to use after the upstreams have been reloaded.
+func (d *Default) upstreams() Nodes {
+	d.nodesMutex.RLock()
+	defer d.nodesMutex.RUnlock()
+
+	return d.nodes
+}
+
+// UpdateUpstreams replaces the set of upstreams. Unchanged upstreams are kept as-is, new or modified upstreams
+// are started and removed upstreams are drained. Cached data and the serving checkpoint are left untouched.
+func (d *Default) UpdateUpstreams(ctx context.Context, configs []node.Config) error {
+	if err := validateUpstreams(configs); err != nil {
+		return err
+	}
+
+	d.nodesMutex.Lock()
+	defer d.nodesMutex.Unlock()
+
+	if d.nodesCtx == nil {
+		return errors.New("provider has not been started")
+	}
+
+	existing := make(map[string]*Node, len(d.nodes))
+	for _, n := range d.nodes {
+		existing[n.Config.Name] = n
+	}
+
+	nodes := make(Nodes, 0, len(configs))
+	added := Nodes{}
+	kept := make(map[*Node]struct{})
+
+	for _, config := range configs {
+		if n, ok := existing[config.Name]; ok && reflect.DeepEqual(n.Config, config) {
+			nodes = append(nodes, n)
+			kept[n] = struct{}{}
+
+			continue
+		}
+
+		_, registered := d.registeredNodes[config.Name]
+		if registered {
+			d.log.WithField("upstream", config.Name).
+				Warn("Upstream has been re-created with the same name, upstream metrics will not be reported until restart")
+		}
+
+		n := NewNodeFromConfig(d.log, config, d.namespace, !registered)
+
+		d.registeredNodes[config.Name] = struct{}{}
+
+		nodes = append(nodes, n)
+		added = append(added, n)
+	}
+
+	removed := Nodes{}
+
+	for _, n := range d.nodes {
+		if _, ok := kept[n]; !ok {
+			removed = append(removed, n)
+		}
+	}
+
+	// Publish the new set first so that no new requests are routed to the removed upstreams.
+	d.nodes = nodes
+
+	for _, n := range added {
+		d.log.WithField("upstream", n.Config.Name).Info("Adding upstream")
+
+		d.startNode(d.nodesCtx, n)
+	}
+
+	for _, n := range removed {
+		d.log.WithField("upstream", n.Config.Name).Info("Draining removed upstream")
+
+		go d.drainNode(d.nodesCtx, n)
+	}
+
+	d.log.WithFields(logrus.Fields{
+		"added":   len(added),
+		"removed": len(removed),
+		"total":   len(nodes),
+	}).Info("Reloaded upstreams")
+
+	return nil
+}
+
+func validateUpstreams(configs []node.Config) error {
+	if len(configs) == 0 {
+		return errors.New("at least one upstream is required")
+	}
+
+	names := make(map[string]struct{}, len(configs))
+
+	for _, config := range configs {
+		if config.Name == "" {
+			return errors.New("upstream name is required")
+		}
+
+		if config.Address == "" {
+			return fmt.Errorf("upstream %s has no address", config.Name)
+		}
+
+		if _, ok := names[config.Name]; ok {
+			return fmt.Errorf("there's a duplicate upstream with the same name: %s", config.Name)
+		}
+
+		names[config.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+// drainNode stops a removed node once its in-flight requests have completed, or NodeDrainTimeout has passed.
+func (d *Default) drainNode(ctx context.Context, n *Node) {
+	ctx, cancel := context.WithTimeout(ctx, NodeDrainTimeout)
+	defer cancel()
+
+	if err := n.inFlight.Wait(ctx); err != nil {
+		d.log.WithError(err).
+			WithField("upstream", n.Config.Name).
+			WithField("in_flight", n.inFlight.Count()).
+			Warn("Stopping removed upstream before its in-flight requests completed")
+	}
+
+	if n.cancel != nil {
+		n.cancel()
+	}
+
+	if err := n.Beacon.Stop(context.Background()); err != nil {
+		d.log.WithError(err).WithField("upstream", n.Config.Name).Error("Failed to stop removed upstream")
+
+		return
+	}
+
+	d.log.WithField("upstream", n.Config.Name).Info("Removed upstream")
+}
+
+// startNode starts the node and subscribes to its finality updates.
+func (d *Default) startNode(ctx context.Context, n *Node) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	n.cancel = cancel
+
+	n.Beacon.StartAsync(ctx)
+
+	logCtx := d.log.WithFields(logrus.Fields{
+		"node":   n.Config.Name,
+		"reason": "serving_updater",
+	})
+
+	n.Beacon.OnFinalityCheckpointUpdated(ctx, func(ctx context.Context, event *beacon.FinalityCheckpointUpdated) error {
+		logCtx.WithFields(logrus.Fields{
+			"epoch": event.Finality.Finalized.Epoch,
+			"root":  fmt.Sprintf("%#x", event.Finality.Finalized.Root),
+		}).Info("Node has a new finalized checkpoint")
+
+		// Check if we have a new majority finality.
+		if err := d.checkFinality(ctx); err != nil {
+			logCtx.WithError(err).Error("Failed to check finality")
+
+			return err
+		}
+
+		if err := d.checkForNewServingCheckpoint(ctx); err != nil {
+			logCtx.WithError(err).Error("Failed to check for new serving checkpoint after finality checkpoint updated")
+
+			return err
+		}
+
+		return nil
+	})
+
+	n.Beacon.OnReady(ctx, func(_ context.Context, _ *beacon.ReadyEvent) error {
+		n.Beacon.Wallclock().OnEpochChanged(func(epoch ethwallclock.Epoch) {
+			// The node has been removed.
+			if ctx.Err() != nil {
+				return
+			}
+
+			// Every upstream sees the epoch change, only the first one refreshes the spec. This will intentionally
+			// use any upstream (not the one that triggered the event) to fetch the spec.
+			if d.claimSpecRefresh(epoch.Number()) {
+				if err := d.refreshSpec(ctx); err != nil {
+					logCtx.WithError(err).Error("Failed to refresh spec")
+				}
+			}
+
+			time.Sleep(time.Second * 5)
+
+			// The node has been removed.
+			if ctx.Err() != nil {
+				return
+			}
+
+			if _, err := n.Beacon.FetchFinality(ctx, "head"); err != nil {
+				logCtx.WithError(err).Error("Failed to fetch finality after epoch transition")
+			}
+
+			if err := d.checkFinality(ctx); err != nil {
+				logCtx.WithError(err).Error("Failed to check finality")
+			}
+
+			if err := d.checkForNewServingCheckpoint(ctx); err != nil {
+				logCtx.WithError(err).Error("Failed to check for new serving checkpoint after epoch change")
+			}
+		})
+
+		return nil
+	})
+}
+
+// claimSpecRefresh returns true if the spec hasn't been refreshed in the given epoch yet, marking it as refreshed.
+func (d *Default) claimSpecRefresh(epoch uint64) bool {
+	d.specRefreshMutex.Lock()
+	defer d.specRefreshMutex.Unlock()
+
+	if d.specRefreshed && epoch <= d.specRefreshedEpoch {
+		return false
+	}
+
+	d.specRefreshed = true
+	d.specRefreshedEpoch = epoch
+
+	return true
+}
@@ -0,0 +1,170 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/checkpointz/pkg/beacon/node"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateUpstreams(t *testing.T) {
+	tests := []struct {
+		name    string
+		configs []node.Config
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			configs: []node.Config{},
+			wantErr: true,
+		},
+		{
+			name: "missing name",
+			configs: []node.Config{
+				{Address: "http://localhost:5052"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing address",
+			configs: []node.Config{
+				{Name: "a"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate name",
+			configs: []node.Config{
+				{Name: "a", Address: "http://localhost:5052"},
+				{Name: "a", Address: "http://localhost:5053"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid",
+			configs: []node.Config{
+				{Name: "a", Address: "http://localhost:5052"},
+				{Name: "b", Address: "http://localhost:5053"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateUpstreams(tt.configs)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdateUpstreams(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+
+	initial := []node.Config{
+		{Name: "reload_a", Address: "http://localhost:5052"},
+		{Name: "reload_b", Address: "http://localhost:5053"},
+	}
+
+	d := &Default{
+		log:             logger,
+		namespace:       "test_update_upstreams",
+		nodes:           NewNodesFromConfig(logger, initial, "test_update_upstreams"),
+		registeredNodes: map[string]struct{}{"reload_a": {}, "reload_b": {}},
+	}
+
+	unchanged := d.nodes[0]
+	modified := d.nodes[1]
+
+	updated := []node.Config{
+		{Name: "reload_a", Address: "http://localhost:5052"},
+		{Name: "reload_b", Address: "http://localhost:6053"},
+		{Name: "reload_c", Address: "http://localhost:5054"},
+	}
+
+	err := d.UpdateUpstreams(context.Background(), updated)
+	require.Error(t, err, "updating before the provider has started should fail")
+	assert.Len(t, d.upstreams(), 2)
+
+	// Cancelled so the nodes don't attempt to connect and removed nodes are stopped immediately.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	d.nodesCtx = ctx
+
+	err = d.UpdateUpstreams(ctx, []node.Config{})
+	require.Error(t, err)
+	assert.Equal(t, Nodes{unchanged, modified}, d.upstreams(), "an invalid config should leave the upstreams untouched")
+
+	err = d.UpdateUpstreams(ctx, updated)
+	require.NoError(t, err)
+
+	nodes := d.upstreams()
+	require.Len(t, nodes, 3)
+
+	assert.Same(t, unchanged, nodes[0])
+	assert.NotSame(t, modified, nodes[1])
+	assert.Equal(t, updated[1], nodes[1].Config)
+	assert.Equal(t, updated[2], nodes[2].Config)
+	assert.Contains(t, d.registeredNodes, "reload_c")
+}
+
+func TestInFlight(t *testing.T) {
+	n := &Node{Config: node.Config{Name: "draining"}}
+
+	require.NoError(t, n.inFlight.Wait(context.Background()), "an idle node shouldn't block")
+
+	release := n.inFlight.Acquire()
+	assert.Equal(t, 1, n.inFlight.Count())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, n.inFlight.Wait(ctx), context.DeadlineExceeded)
+
+	done := make(chan error)
+
+	go func() {
+		done <- n.inFlight.Wait(context.Background())
+	}()
+
+	release()
+	release()
+
+	require.NoError(t, <-done)
+	assert.Equal(t, 0, n.inFlight.Count(), "releasing twice should only count once")
+}
+
+func TestTryUpstreamsTracksInFlightRequests(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+
+	d := &Default{
+		log: logger,
+	}
+
+	upstream := &Node{Config: node.Config{Name: "a"}}
+
+	err := d.tryUpstreams(Nodes{upstream}, func(upstream *Node) error {
+		assert.Equal(t, 1, upstream.inFlight.Count())
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, upstream.inFlight.Count())
+}
+
+func TestClaimSpecRefresh(t *testing.T) {
+	d := &Default{}
+
+	assert.True(t, d.claimSpecRefresh(0))
+	assert.False(t, d.claimSpecRefresh(0), "only one upstream should refresh the spec per epoch")
+	assert.True(t, d.claimSpecRefresh(1))
+	assert.False(t, d.claimSpecRefresh(0))
+}
@@ -137,6 +137,19 @@ func (m *TTLMap) len() int {
 	return len(m.m)
 }
 
+// Keys returns the keys of all the items in the map.
+func (m *TTLMap) Keys() []string {
+	m.l.RLock()
+	defer m.l.RUnlock()
+
+	keys := make([]string, 0, len(m.m))
+	for k := range m.m {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
 func (m *TTLMap) Add(k string, v interface{}, expiresAt time.Time, invincible bool) {
 	m.l.Lock()
 	defer m.l.Unlock()
@@ -39,6 +39,24 @@ func TestItemDeletes(t *testing.T) {
 	}
 }
 
+func TestKeys(t *testing.T) {
+	instance := NewTTLMap(10, "", "")
+
+	instance.Add("key1", "value1", time.Now().Add(time.Hour), false)
+	instance.Add("key2", "value2", time.Now().Add(time.Hour), false)
+
+	keys := instance.Keys()
+	if len(keys) != 2 {
+		t.Fatalf("Expected 2 keys, got %d", len(keys))
+	}
+
+	for _, key := range []string{"key1", "key2"} {
+		if keys[0] != key && keys[1] != key {
+			t.Fatalf("Expected %s to be in %v", key, keys)
+		}
+	}
+}
+
 func TestItemDoesExpire(t *testing.T) {
 	instance := NewTTLMap(10, "", "")
 
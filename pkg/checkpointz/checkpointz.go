@@ -2,8 +2,10 @@ package checkpointz
 
 import (
 	"context"
+	"errors"
 	"io/fs"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/ethpandaops/checkpointz/pkg/api"
@@ -20,6 +22,9 @@ var (
 	namespace = "checkpointz"
 )
 
+// ShutdownTimeout is how long in-flight requests are given to finish when shutting down.
+const ShutdownTimeout = 30 * time.Second
+
 type Server struct {
 	log *logrus.Logger
 	Cfg Config
@@ -27,18 +32,28 @@ type Server struct {
 	provider beacon.FinalityProvider
 
 	http *api.Handler
+
+	configLoader ConfigLoader
+	reloadMutex  sync.Mutex
 }
 
 func NewServer(log *logrus.Logger, conf *Config) *Server {
+	return NewServerWithStorage(log, conf, beacon.NewMemoryStorage(log, conf.Checkpointz.Caches, namespace))
+}
+
+// NewServerWithStorage returns a new Server that stores downloaded blocks and states in the given storage
+// instead of in memory.
+func NewServerWithStorage(log *logrus.Logger, conf *Config, storage beacon.Storage) *Server {
 	if err := conf.Validate(); err != nil {
 		log.Fatalf("invalid config: %s", err)
 	}
 
-	provider := beacon.NewDefaultProvider(
+	provider := beacon.NewDefaultProviderWithStorage(
 		namespace,
 		log,
 		conf.BeaconConfig.BeaconUpstreams,
 		&conf.Checkpointz,
+		storage,
 	)
 
 	s := &Server{
@@ -58,6 +73,10 @@ func (s *Server) Start(ctx context.Context) error {
 
 	s.provider.StartAsync(ctx)
 
+	if s.configLoader != nil {
+		s.watchReloadSignal(ctx)
+	}
+
 	router := httprouter.New()
 
 	if err := s.http.Register(ctx, router); err != nil {
@@ -77,32 +96,85 @@ func (s *Server) Start(ctx context.Context) error {
 		return err
 	}
 
+	if s.Cfg.GlobalConfig.AdminAddr != "" {
+		if err := s.ServeAdmin(ctx); err != nil {
+			return err
+		}
+	}
+
 	server := &http.Server{
 		Addr:              s.Cfg.GlobalConfig.ListenAddr,
 		ReadHeaderTimeout: 3 * time.Minute,
 		WriteTimeout:      15 * time.Minute,
 	}
 
-	// Gzip any content longer than 1024 bytes if requested via the Accept-Encoding header
+	// Gzip any content longer than 1024 bytes if it's the preferred encoding in the Accept-Encoding header
 	gzipHandler := gzip.NewHandler(gzip.Config{
 		CompressionLevel: 6,
 		MinContentLength: 1024,
 		RequestFilter: []gzip.RequestFilter{
-			gzip.NewCommonRequestFilter(),
+			&contentEncodingFilter{},
 		},
 		ResponseHeaderFilter: []gzip.ResponseHeaderFilter{},
 	})
 	server.Handler = gzipHandler.WrapHandler(router)
 
-	s.log.Infof("Serving http at %s", s.Cfg.GlobalConfig.ListenAddr)
+	listen := server.ListenAndServe
+
+	if s.Cfg.GlobalConfig.TLS.Enabled {
+		tlsConfig, err := s.newTLSConfig(ctx)
+		if err != nil {
+			return err
+		}
 
-	if err := server.ListenAndServe(); err != nil {
+		server.TLSConfig = tlsConfig
+
+		s.log.Infof("Serving https at %s", s.Cfg.GlobalConfig.ListenAddr)
+
+		// The certificate is provided by the TLS config so it can be reloaded.
+		listen = func() error {
+			return server.ListenAndServeTLS("", "")
+		}
+	} else {
+		s.log.Infof("Serving http at %s", s.Cfg.GlobalConfig.ListenAddr)
+	}
+
+	if err := s.serve(ctx, server, "http", listen); err != nil {
 		s.log.Fatal(err)
 	}
 
 	return nil
 }
 
+// serve runs listen until the context is cancelled and then gracefully shuts the server down. It only returns once
+// in-flight requests have finished or ShutdownTimeout has passed.
+func (s *Server) serve(ctx context.Context, server *http.Server, name string, listen func() error) error {
+	shutdownDone := make(chan struct{})
+
+	go func() {
+		defer close(shutdownDone)
+
+		<-ctx.Done()
+
+		s.log.Infof("Shutting down %s server", name)
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+		defer cancel()
+
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			s.log.WithError(err).Errorf("Failed to gracefully shutdown %s server", name)
+		}
+	}()
+
+	if err := listen(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	<-shutdownDone
+
+	return nil
+}
+
 func (s *Server) ServeMetrics(ctx context.Context) error {
 	go func() {
 		server := &http.Server{
@@ -121,3 +193,28 @@ func (s *Server) ServeMetrics(ctx context.Context) error {
 
 	return nil
 }
+
+func (s *Server) ServeAdmin(ctx context.Context) error {
+	router := httprouter.New()
+
+	if err := s.http.RegisterAdmin(ctx, router); err != nil {
+		return err
+	}
+
+	go func() {
+		server := &http.Server{
+			Addr:              s.Cfg.GlobalConfig.AdminAddr,
+			ReadHeaderTimeout: 15 * time.Second,
+		}
+
+		server.Handler = router
+
+		s.log.Infof("Serving admin http at %s", s.Cfg.GlobalConfig.AdminAddr)
+
+		if err := s.serve(ctx, server, "admin", server.ListenAndServe); err != nil {
+			s.log.Fatal(err)
+		}
+	}()
+
+	return nil
+}
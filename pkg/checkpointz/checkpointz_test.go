@@ -0,0 +1,77 @@
+package checkpointz
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeWaitsForInFlightRequests(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	s := &Server{log: logger}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	started := make(chan struct{})
+	finished := make(chan struct{})
+
+	server := &http.Server{
+		ReadHeaderTimeout: time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+
+			time.Sleep(200 * time.Millisecond)
+
+			w.WriteHeader(http.StatusOK)
+
+			close(finished)
+		}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	served := make(chan error, 1)
+
+	go func() {
+		served <- s.serve(ctx, server, "test", func() error {
+			return server.Serve(listener)
+		})
+	}()
+
+	responded := make(chan int, 1)
+
+	go func() {
+		rsp, err := http.Get("http://" + listener.Addr().String())
+		if err != nil {
+			responded <- 0
+
+			return
+		}
+
+		defer rsp.Body.Close()
+
+		responded <- rsp.StatusCode
+	}()
+
+	<-started
+
+	cancel()
+
+	require.NoError(t, <-served)
+
+	select {
+	case <-finished:
+	default:
+		t.Fatal("serve returned before the in-flight request finished")
+	}
+
+	assert.Equal(t, http.StatusOK, <-responded)
+}
@@ -14,9 +14,11 @@ type Config struct {
 }
 
 type GlobalConfig struct {
-	ListenAddr   string `yaml:"listenAddr" default:":5555"`
-	LoggingLevel string `yaml:"logging" default:"warn"`
-	MetricsAddr  string `yaml:"metricsAddr" default:":9090"`
+	ListenAddr   string    `yaml:"listenAddr" default:":5555"`
+	LoggingLevel string    `yaml:"logging" default:"warn"`
+	MetricsAddr  string    `yaml:"metricsAddr" default:":9090"`
+	AdminAddr    string    `yaml:"adminAddr"`
+	TLS          TLSConfig `yaml:"tls"`
 }
 
 type BeaconConfig struct {
@@ -40,6 +42,10 @@ func (c *Config) Validate() error {
 		duplicates[u.Address] = struct{}{}
 	}
 
+	if err := c.GlobalConfig.TLS.Validate(); err != nil {
+		return fmt.Errorf("invalid tls config: %s", err)
+	}
+
 	if err := c.Checkpointz.Validate(); err != nil {
 		return fmt.Errorf("invalid checkpointz config: %s", err)
 	}
@@ -0,0 +1,21 @@
+package checkpointz
+
+import (
+	"net/http"
+
+	"github.com/ethpandaops/checkpointz/pkg/api"
+	"github.com/nanmu42/gzip"
+)
+
+// contentEncodingFilter only compresses responses when gzip is the client's preferred encoding according to the
+// q-values in the Accept-Encoding header.
+type contentEncodingFilter struct{}
+
+var _ gzip.RequestFilter = (*contentEncodingFilter)(nil)
+
+func (f *contentEncodingFilter) ShouldCompress(r *http.Request) bool {
+	return r.Method != http.MethodHead &&
+		r.Method != http.MethodOptions &&
+		r.Header.Get("Upgrade") == "" &&
+		api.NegotiateContentEncoding(r.Header.Get("Accept-Encoding")) == api.ContentEncodingGzip
+}
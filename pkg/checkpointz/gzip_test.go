@@ -0,0 +1,38 @@
+package checkpointz
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentEncodingFilter(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		acceptEncoding string
+		expected       bool
+	}{
+		{"No Accept-Encoding", http.MethodGet, "", false},
+		{"Gzip", http.MethodGet, "gzip", true},
+		{"Gzip preferred", http.MethodGet, "gzip;q=0.5, br;q=1.0", true},
+		{"Gzip disallowed", http.MethodGet, "identity;q=1, gzip;q=0", false},
+		{"Identity preferred", http.MethodGet, "identity;q=1, gzip;q=0.5", false},
+		{"HEAD", http.MethodHead, "gzip", false},
+	}
+
+	filter := &contentEncodingFilter{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/eth/v2/debug/beacon/states/finalized", nil)
+			if tt.acceptEncoding != "" {
+				r.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+
+			assert.Equal(t, tt.expected, filter.ShouldCompress(r))
+		})
+	}
+}
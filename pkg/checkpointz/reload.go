@@ -0,0 +1,93 @@
+package checkpointz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"syscall"
+)
+
+// ConfigLoader loads a fresh copy of the config, e.g. by re-reading the config file.
+type ConfigLoader func() (*Config, error)
+
+// SetConfigLoader enables hot-reloading of the beacon upstreams via SIGHUP and the admin api.
+func (s *Server) SetConfigLoader(loader ConfigLoader) {
+	s.configLoader = loader
+
+	s.http.SetConfigReloader(func(ctx context.Context) ([]string, error) {
+		if err := s.ReloadUpstreams(ctx); err != nil {
+			return nil, err
+		}
+
+		s.reloadMutex.Lock()
+		defer s.reloadMutex.Unlock()
+
+		names := make([]string, 0, len(s.Cfg.BeaconConfig.BeaconUpstreams))
+		for _, upstream := range s.Cfg.BeaconConfig.BeaconUpstreams {
+			names = append(names, upstream.Name)
+		}
+
+		return names, nil
+	})
+}
+
+// ReloadUpstreams loads the config and applies any changes to the beacon upstreams. The new config is validated
+// before being applied; if anything fails the previous config remains in use. Only the upstreams are reloaded,
+// all other config changes require a restart.
+func (s *Server) ReloadUpstreams(ctx context.Context) error {
+	if s.configLoader == nil {
+		return errors.New("config reloading is not enabled")
+	}
+
+	s.reloadMutex.Lock()
+	defer s.reloadMutex.Unlock()
+
+	conf, err := s.configLoader()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	if reflect.DeepEqual(conf.BeaconConfig, s.Cfg.BeaconConfig) {
+		s.log.Info("Beacon upstreams are unchanged, nothing to reload")
+
+		return nil
+	}
+
+	if err := s.provider.UpdateUpstreams(ctx, conf.BeaconConfig.BeaconUpstreams); err != nil {
+		return fmt.Errorf("failed to apply upstreams, keeping previous config: %w", err)
+	}
+
+	s.Cfg.BeaconConfig = conf.BeaconConfig
+
+	return nil
+}
+
+func (s *Server) watchReloadSignal(ctx context.Context) {
+	sighup := make(chan os.Signal, 1)
+
+	signal.Notify(sighup, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(sighup)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sighup:
+				s.log.Info("Received SIGHUP, reloading beacon upstreams")
+
+				if err := s.ReloadUpstreams(ctx); err != nil {
+					s.log.WithError(err).Error("Failed to reload beacon upstreams")
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,237 @@
+package checkpointz
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethpandaops/checkpointz/pkg/human"
+	"github.com/sirupsen/logrus"
+)
+
+// TLSConfig holds configuration for terminating TLS in the main http server.
+type TLSConfig struct {
+	// Enabled serves the main http server over TLS.
+	Enabled bool `yaml:"enabled"`
+	// CertFile is the path to the PEM encoded certificate (chain).
+	CertFile string `yaml:"certFile"`
+	// KeyFile is the path to the PEM encoded private key.
+	KeyFile string `yaml:"keyFile"`
+	// MinVersion is the minimum TLS version that will be accepted (1.0, 1.1, 1.2 or 1.3).
+	MinVersion string `yaml:"minVersion" default:"1.2"`
+	// CipherSuites restricts the cipher suites used for TLS 1.0-1.2. TLS 1.3 cipher suites are not configurable.
+	CipherSuites []string `yaml:"cipherSuites"`
+	// ReloadInterval controls how often the certificate and key are checked for changes. Disabled if 0.
+	ReloadInterval human.Duration `yaml:"reloadInterval"`
+}
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+func (c *TLSConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.CertFile == "" {
+		return errors.New("certFile is required")
+	}
+
+	if c.KeyFile == "" {
+		return errors.New("keyFile is required")
+	}
+
+	if _, err := c.minVersion(); err != nil {
+		return err
+	}
+
+	if _, err := c.cipherSuites(); err != nil {
+		return err
+	}
+
+	if c.ReloadInterval.Duration < 0 {
+		return errors.New("reloadInterval must not be negative")
+	}
+
+	return nil
+}
+
+func (c *TLSConfig) minVersion() (uint16, error) {
+	if c.MinVersion == "" {
+		return tls.VersionTLS12, nil
+	}
+
+	version, ok := tlsVersions[c.MinVersion]
+	if !ok {
+		return 0, fmt.Errorf("unsupported minVersion: %s", c.MinVersion)
+	}
+
+	return version, nil
+}
+
+func (c *TLSConfig) cipherSuites() ([]uint16, error) {
+	if len(c.CipherSuites) == 0 {
+		// Let the standard library pick its (secure) defaults.
+		return nil, nil
+	}
+
+	supported := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		supported[suite.Name] = suite.ID
+	}
+
+	suites := make([]uint16, 0, len(c.CipherSuites))
+
+	for _, name := range c.CipherSuites {
+		id, ok := supported[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported or insecure cipher suite: %s", name)
+		}
+
+		suites = append(suites, id)
+	}
+
+	return suites, nil
+}
+
+// certificateReloader serves the most recently loaded certificate, reloading it from disk when it changes.
+type certificateReloader struct {
+	log logrus.FieldLogger
+
+	certFile string
+	keyFile  string
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+func newCertificateReloader(log logrus.FieldLogger, certFile, keyFile string) (*certificateReloader, error) {
+	r := &certificateReloader{
+		log:      log.WithField("module", "checkpointz/tls"),
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+
+	if _, err := r.reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *certificateReloader) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.cert, nil
+}
+
+// reload loads the certificate and key if either file has changed since the last load.
+func (r *certificateReloader) reload() (bool, error) {
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	unchanged := r.cert != nil && modTime.Equal(r.modTime)
+	r.mu.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to load tls key pair: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cert = &cert
+	r.modTime = modTime
+
+	return true, nil
+}
+
+func (r *certificateReloader) latestModTime() (time.Time, error) {
+	latest := time.Time{}
+
+	for _, file := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+
+	return latest, nil
+}
+
+// Watch periodically reloads the certificate until the context is cancelled. The previous certificate
+// is kept if a reload fails (e.g. halfway through a rotation).
+func (r *certificateReloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := r.reload()
+			if err != nil {
+				r.log.WithError(err).Error("Failed to reload tls certificate, continuing to serve the previous certificate")
+
+				continue
+			}
+
+			if reloaded {
+				r.log.Info("Reloaded tls certificate")
+			}
+		}
+	}
+}
+
+func (s *Server) newTLSConfig(ctx context.Context) (*tls.Config, error) {
+	cfg := s.Cfg.GlobalConfig.TLS
+
+	minVersion, err := cfg.minVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	cipherSuites, err := cfg.cipherSuites()
+	if err != nil {
+		return nil, err
+	}
+
+	reloader, err := newCertificateReloader(s.log, cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.ReloadInterval.Duration > 0 {
+		go reloader.Watch(ctx, cfg.ReloadInterval.Duration)
+	}
+
+	//nolint:gosec // min version is configurable and defaults to 1.2.
+	return &tls.Config{
+		MinVersion:     minVersion,
+		CipherSuites:   cipherSuites,
+		GetCertificate: reloader.GetCertificate,
+	}, nil
+}
@@ -0,0 +1,173 @@
+package checkpointz
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/checkpointz/pkg/human"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLSConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      TLSConfig
+		expectError bool
+	}{
+		{"Disabled", TLSConfig{}, false},
+		{"Valid", TLSConfig{Enabled: true, CertFile: "cert.pem", KeyFile: "key.pem", MinVersion: "1.2"}, false},
+		{"Missing cert", TLSConfig{Enabled: true, KeyFile: "key.pem"}, true},
+		{"Missing key", TLSConfig{Enabled: true, CertFile: "cert.pem"}, true},
+		{"Invalid min version", TLSConfig{Enabled: true, CertFile: "cert.pem", KeyFile: "key.pem", MinVersion: "1.4"}, true},
+		{"Valid cipher suite", TLSConfig{Enabled: true, CertFile: "cert.pem", KeyFile: "key.pem", CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}}, false},
+		{"Insecure cipher suite", TLSConfig{Enabled: true, CertFile: "cert.pem", KeyFile: "key.pem", CipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}}, true},
+		{"Reload disabled", TLSConfig{Enabled: true, CertFile: "cert.pem", KeyFile: "key.pem"}, false},
+		{"Negative reload interval", TLSConfig{Enabled: true, CertFile: "cert.pem", KeyFile: "key.pem", ReloadInterval: human.Duration{Duration: -time.Second}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTLSConfigParsing(t *testing.T) {
+	config := TLSConfig{
+		MinVersion:   "1.3",
+		CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"},
+	}
+
+	version, err := config.minVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), version)
+
+	suites, err := config.cipherSuites()
+	assert.NoError(t, err)
+	assert.Equal(t, []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384}, suites)
+}
+
+// keyPair is a self-signed PEM encoded certificate and key.
+type keyPair struct {
+	cert []byte
+	key  []byte
+}
+
+func newKeyPair(t *testing.T, commonName string) keyPair {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return keyPair{
+		cert: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		key:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+// writeKeyPair writes the certificate and key, setting their modification time explicitly as the file system's
+// timestamp granularity can be too coarse to notice a rotation within a test.
+func writeKeyPair(t *testing.T, certFile, keyFile string, cert, key []byte, modTime time.Time) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(certFile, cert, 0o600))
+	require.NoError(t, os.WriteFile(keyFile, key, 0o600))
+
+	require.NoError(t, os.Chtimes(certFile, modTime, modTime))
+	require.NoError(t, os.Chtimes(keyFile, modTime, modTime))
+}
+
+func servedCommonName(t *testing.T, r *certificateReloader) string {
+	t.Helper()
+
+	cert, err := r.GetCertificate(nil)
+	require.NoError(t, err)
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+
+	return leaf.Subject.CommonName
+}
+
+func TestCertificateReloader(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+
+	original := newKeyPair(t, "original")
+	rotated := newKeyPair(t, "rotated")
+
+	modTime := time.Now().Add(-time.Minute)
+
+	writeKeyPair(t, certFile, keyFile, original.cert, original.key, modTime)
+
+	r, err := newCertificateReloader(logger, certFile, keyFile)
+	require.NoError(t, err)
+	assert.Equal(t, "original", servedCommonName(t, r))
+
+	t.Run("unchanged", func(t *testing.T) {
+		reloaded, err := r.reload()
+		require.NoError(t, err)
+		assert.False(t, reloaded)
+		assert.Equal(t, "original", servedCommonName(t, r))
+	})
+
+	t.Run("mismatched pair", func(t *testing.T) {
+		modTime = modTime.Add(time.Second)
+
+		// Halfway through a rotation: the new certificate with the old key.
+		writeKeyPair(t, certFile, keyFile, rotated.cert, original.key, modTime)
+
+		reloaded, err := r.reload()
+		assert.Error(t, err)
+		assert.False(t, reloaded)
+		assert.Equal(t, "original", servedCommonName(t, r), "the previous certificate should still be served")
+	})
+
+	t.Run("rotated", func(t *testing.T) {
+		modTime = modTime.Add(time.Second)
+
+		writeKeyPair(t, certFile, keyFile, rotated.cert, rotated.key, modTime)
+
+		reloaded, err := r.reload()
+		require.NoError(t, err)
+		assert.True(t, reloaded)
+		assert.Equal(t, "rotated", servedCommonName(t, r))
+
+		reloaded, err = r.reload()
+		require.NoError(t, err)
+		assert.False(t, reloaded)
+	})
+}
@@ -21,3 +21,12 @@ func CalculateSlotTime(slot phase0.Slot, genesisTime time.Time, durationPerSlot
 		EndTime:   slotStartTime.Add(durationPerSlot),
 	}
 }
+
+// CalculateSlotAtTime returns the slot that is in progress at the given time. Times before genesis are slot 0.
+func CalculateSlotAtTime(t, genesisTime time.Time, durationPerSlot time.Duration) phase0.Slot {
+	if durationPerSlot <= 0 || !t.After(genesisTime) {
+		return phase0.Slot(0)
+	}
+
+	return phase0.Slot(t.Sub(genesisTime) / durationPerSlot)
+}
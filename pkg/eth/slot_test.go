@@ -52,3 +52,43 @@ func TestCalculateSlotTime(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateSlotAtTime(t *testing.T) {
+	genesisTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	durationPerSlot := time.Second * 12
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want phase0.Slot
+	}{
+		{
+			name: "before genesis",
+			at:   genesisTime.Add(-time.Hour),
+			want: phase0.Slot(0),
+		},
+		{
+			name: "genesis",
+			at:   genesisTime,
+			want: phase0.Slot(0),
+		},
+		{
+			name: "mid slot",
+			at:   genesisTime.Add(30 * time.Second),
+			want: phase0.Slot(2),
+		},
+		{
+			name: "slot start",
+			at:   time.Date(2020, 1, 1, 0, 20, 0, 0, time.UTC),
+			want: phase0.Slot(100),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := CalculateSlotAtTime(test.at, genesisTime, durationPerSlot); got != test.want {
+				t.Errorf("CalculateSlotAtTime() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package eth
 
 import (
+	"encoding/hex"
 	"fmt"
+	"strings"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 )
@@ -10,6 +12,24 @@ func RootAsString(root phase0.Root) string {
 	return fmt.Sprintf("%#x", root)
 }
 
+// RootFromString parses a 0x prefixed hex string as created by RootAsString.
+func RootFromString(s string) (phase0.Root, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return phase0.Root{}, fmt.Errorf("invalid root: %w", err)
+	}
+
+	root := phase0.Root{}
+
+	if len(b) != len(root) {
+		return phase0.Root{}, fmt.Errorf("incorrect length %d for root", len(b))
+	}
+
+	copy(root[:], b)
+
+	return root, nil
+}
+
 func SlotAsString(slot phase0.Slot) string {
 	return fmt.Sprintf("%d", slot)
 }
@@ -34,6 +34,27 @@ func TestRootToString(t *testing.T) {
 	}
 }
 
+func TestRootFromString(t *testing.T) {
+	root := phase0.Root{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20}
+
+	got, err := RootFromString(RootAsString(root))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != root {
+		t.Errorf("RootFromString() = %v, want %v", got, root)
+	}
+
+	if _, err := RootFromString("0x0102"); err == nil {
+		t.Error("RootFromString() expected an error for a short root")
+	}
+
+	if _, err := RootFromString("0xzz"); err == nil {
+		t.Error("RootFromString() expected an error for invalid hex")
+	}
+}
+
 func TestSlotToString(t *testing.T) {
 	tests := []struct {
 		name string
@@ -3,6 +3,7 @@ package checkpointz
 import (
 	"context"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/checkpointz/pkg/beacon"
 	"github.com/ethpandaops/checkpointz/pkg/eth"
 	"github.com/ethpandaops/checkpointz/pkg/version"
@@ -43,6 +44,13 @@ func (h *Handler) V1Status(ctx context.Context, req *StatusRequest) (*StatusResp
 
 	response.Upstreams = upstreams
 
+	healthy, err := h.provider.Healthy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Healthy = healthy
+
 	finality, err := h.provider.Finalized(ctx)
 	if err != nil {
 		return nil, err
@@ -52,6 +60,13 @@ func (h *Handler) V1Status(ctx context.Context, req *StatusRequest) (*StatusResp
 		response.Finality = finality
 	}
 
+	backfill, err := h.provider.BackfillStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Backfill = backfill
+
 	return response, nil
 }
 
@@ -116,3 +131,50 @@ func (h *Handler) V1BeaconSlot(ctx context.Context, req *BeaconSlotRequest) (*Be
 
 	return response, nil
 }
+
+// V1Backfill returns the progress of the historical backfill.
+func (h *Handler) V1Backfill(ctx context.Context, req *BackfillRequest) (*BackfillResponse, error) {
+	status, err := h.provider.BackfillStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BackfillResponse{
+		Backfill: status,
+	}, nil
+}
+
+// V1PauseBackfill pauses the historical backfill.
+func (h *Handler) V1PauseBackfill(ctx context.Context, req *BackfillRequest) (*BackfillResponse, error) {
+	if err := h.provider.PauseBackfill(ctx); err != nil {
+		return nil, err
+	}
+
+	return h.V1Backfill(ctx, req)
+}
+
+// V1ResumeBackfill resumes the historical backfill.
+func (h *Handler) V1ResumeBackfill(ctx context.Context, req *BackfillRequest) (*BackfillResponse, error) {
+	if err := h.provider.ResumeBackfill(ctx); err != nil {
+		return nil, err
+	}
+
+	return h.V1Backfill(ctx, req)
+}
+
+// V1BeaconGenesisValidatorsRoot returns the genesis validators root of the chain.
+func (h *Handler) V1BeaconGenesisValidatorsRoot(ctx context.Context, req *GenesisValidatorsRootRequest) (*GenesisValidatorsRootResponse, error) {
+	genesis, err := h.provider.Genesis(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GenesisValidatorsRootResponse{
+		GenesisValidatorsRoot: eth.RootAsString(genesis.GenesisValidatorsRoot),
+	}, nil
+}
+
+// SlotTime returns the wall clock time of the given slot.
+func (h *Handler) SlotTime(ctx context.Context, slot phase0.Slot) (eth.SlotTime, error) {
+	return h.provider.GetSlotTime(ctx, slot)
+}
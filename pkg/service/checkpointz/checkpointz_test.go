@@ -0,0 +1,87 @@
+package checkpointz
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/checkpointz/pkg/beacon"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeGenesisProvider struct {
+	beacon.FinalityProvider
+
+	genesis *v1.Genesis
+}
+
+func (f *fakeGenesisProvider) Genesis(ctx context.Context) (*v1.Genesis, error) {
+	if f.genesis == nil {
+		return nil, errors.New("genesis bundle not yet available")
+	}
+
+	return f.genesis, nil
+}
+
+func TestV1BeaconGenesisValidatorsRoot(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	provider := &fakeGenesisProvider{}
+	handler := NewHandler(logger, provider)
+
+	_, err := handler.V1BeaconGenesisValidatorsRoot(context.Background(), NewGenesisValidatorsRootRequest())
+	assert.Error(t, err)
+
+	provider.genesis = &v1.Genesis{
+		GenesisValidatorsRoot: phase0.Root{0x4b, 0x36, 0x3d, 0xb9},
+	}
+
+	rsp, err := handler.V1BeaconGenesisValidatorsRoot(context.Background(), NewGenesisValidatorsRootRequest())
+	require.NoError(t, err)
+	assert.Equal(t, "0x4b363db900000000000000000000000000000000000000000000000000000000", rsp.GenesisValidatorsRoot)
+}
+
+type fakeStatusProvider struct {
+	beacon.FinalityProvider
+
+	healthy bool
+}
+
+func (f *fakeStatusProvider) OperatingMode() beacon.OperatingMode {
+	return beacon.OperatingModeLight
+}
+
+func (f *fakeStatusProvider) UpstreamsStatus(ctx context.Context) (map[string]*beacon.UpstreamStatus, error) {
+	return map[string]*beacon.UpstreamStatus{}, nil
+}
+
+func (f *fakeStatusProvider) Healthy(ctx context.Context) (bool, error) {
+	return f.healthy, nil
+}
+
+func (f *fakeStatusProvider) Finalized(ctx context.Context) (*v1.Finality, error) {
+	return nil, nil
+}
+
+func (f *fakeStatusProvider) BackfillStatus(ctx context.Context) (*beacon.BackfillStatus, error) {
+	return &beacon.BackfillStatus{}, nil
+}
+
+func TestV1StatusHealthy(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	provider := &fakeStatusProvider{}
+	handler := NewHandler(logger, provider)
+
+	rsp, err := handler.V1Status(context.Background(), NewStatusRequest())
+	require.NoError(t, err)
+	assert.False(t, rsp.Healthy)
+
+	provider.healthy = true
+
+	rsp, err = handler.V1Status(context.Background(), NewStatusRequest())
+	require.NoError(t, err)
+	assert.True(t, rsp.Healthy)
+}
@@ -37,3 +37,25 @@ func NewBeaconSlotRequest(slot phase0.Slot) *BeaconSlotRequest {
 		slot: slot,
 	}
 }
+
+type BackfillRequest struct {
+}
+
+func (r *BackfillRequest) Validate() error {
+	return nil
+}
+
+func NewBackfillRequest() *BackfillRequest {
+	return &BackfillRequest{}
+}
+
+type GenesisValidatorsRootRequest struct {
+}
+
+func (r *GenesisValidatorsRootRequest) Validate() error {
+	return nil
+}
+
+func NewGenesisValidatorsRootRequest() *GenesisValidatorsRootRequest {
+	return &GenesisValidatorsRootRequest{}
+}
@@ -10,12 +10,14 @@ import (
 
 type StatusResponse struct {
 	Upstreams     map[string]*beacon.UpstreamStatus `json:"upstreams"`
+	Healthy       bool                              `json:"healthy"`
 	Finality      *v1.Finality                      `json:"finality"`
 	PublicURL     string                            `json:"public_url,omitempty"`
 	BrandName     string                            `json:"brand_name,omitempty"`
 	BrandImageURL string                            `json:"brand_image_url,omitempty"`
 	Version       Version                           `json:"version"`
 	OperatingMode beacon.OperatingMode              `json:"operating_mode"`
+	Backfill      *beacon.BackfillStatus            `json:"backfill,omitempty"`
 }
 
 type Version struct {
@@ -42,3 +44,11 @@ type BeaconSlotResponse struct {
 	Epoch    phase0.Epoch                     `json:"epoch"`
 	SlotTime eth.SlotTime                     `json:"time"`
 }
+
+type BackfillResponse struct {
+	Backfill *beacon.BackfillStatus `json:"backfill"`
+}
+
+type GenesisValidatorsRootResponse struct {
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+}
@@ -345,11 +345,26 @@ func (h *Handler) FinalityCheckpoints(ctx context.Context, stateID StateIdentifi
 			return nil, err
 		}
 
-		if finality.Finalized == nil {
+		if finality == nil || finality.Finalized == nil {
 			return nil, fmt.Errorf("no finalized state known")
 		}
 
-		return finality, nil
+		// States are only available in full mode. When we have the finalized state, use its own
+		// view of finality rather than the head's view.
+		if h.provider.OperatingMode() != beacon.OperatingModeFull {
+			if finality.Justified == nil || finality.PreviousJustified == nil {
+				return nil, fmt.Errorf("justified checkpoints not yet known")
+			}
+
+			return finality, nil
+		}
+
+		state, err := h.provider.GetBeaconStateByRoot(ctx, finality.Finalized.Root)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewFinalityFromBeaconState(state)
 	default:
 		return nil, fmt.Errorf("invalid state id: %v", stateID.String())
 	}
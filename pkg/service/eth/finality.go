@@ -0,0 +1,74 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// NewFinalityFromBeaconState returns the finality checkpoints as seen by the given beacon state.
+func NewFinalityFromBeaconState(state *spec.VersionedBeaconState) (*v1.Finality, error) {
+	if state == nil {
+		return nil, errors.New("state is nil")
+	}
+
+	var previousJustified, currentJustified, finalized *phase0.Checkpoint
+
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		if state.Phase0 == nil {
+			return nil, errors.New("no phase0 state")
+		}
+
+		previousJustified = state.Phase0.PreviousJustifiedCheckpoint
+		currentJustified = state.Phase0.CurrentJustifiedCheckpoint
+		finalized = state.Phase0.FinalizedCheckpoint
+	case spec.DataVersionAltair:
+		if state.Altair == nil {
+			return nil, errors.New("no altair state")
+		}
+
+		previousJustified = state.Altair.PreviousJustifiedCheckpoint
+		currentJustified = state.Altair.CurrentJustifiedCheckpoint
+		finalized = state.Altair.FinalizedCheckpoint
+	case spec.DataVersionBellatrix:
+		if state.Bellatrix == nil {
+			return nil, errors.New("no bellatrix state")
+		}
+
+		previousJustified = state.Bellatrix.PreviousJustifiedCheckpoint
+		currentJustified = state.Bellatrix.CurrentJustifiedCheckpoint
+		finalized = state.Bellatrix.FinalizedCheckpoint
+	case spec.DataVersionCapella:
+		if state.Capella == nil {
+			return nil, errors.New("no capella state")
+		}
+
+		previousJustified = state.Capella.PreviousJustifiedCheckpoint
+		currentJustified = state.Capella.CurrentJustifiedCheckpoint
+		finalized = state.Capella.FinalizedCheckpoint
+	case spec.DataVersionDeneb:
+		if state.Deneb == nil {
+			return nil, errors.New("no deneb state")
+		}
+
+		previousJustified = state.Deneb.PreviousJustifiedCheckpoint
+		currentJustified = state.Deneb.CurrentJustifiedCheckpoint
+		finalized = state.Deneb.FinalizedCheckpoint
+	default:
+		return nil, fmt.Errorf("unknown state version: %s", state.Version.String())
+	}
+
+	if previousJustified == nil || currentJustified == nil || finalized == nil {
+		return nil, errors.New("state is missing finality checkpoints")
+	}
+
+	return &v1.Finality{
+		PreviousJustified: previousJustified,
+		Justified:         currentJustified,
+		Finalized:         finalized,
+	}, nil
+}
@@ -0,0 +1,127 @@
+package eth
+
+import (
+	"context"
+	"testing"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/checkpointz/pkg/beacon"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFinalityProvider struct {
+	beacon.FinalityProvider
+
+	mode      beacon.OperatingMode
+	head      *v1.Finality
+	finalized *v1.Finality
+	states    map[phase0.Root]*spec.VersionedBeaconState
+}
+
+func (f *fakeFinalityProvider) OperatingMode() beacon.OperatingMode {
+	return f.mode
+}
+
+func (f *fakeFinalityProvider) Head(ctx context.Context) (*v1.Finality, error) {
+	return f.head, nil
+}
+
+func (f *fakeFinalityProvider) Finalized(ctx context.Context) (*v1.Finality, error) {
+	return f.finalized, nil
+}
+
+func (f *fakeFinalityProvider) GetBeaconStateByRoot(ctx context.Context, root phase0.Root) (*spec.VersionedBeaconState, error) {
+	return f.states[root], nil
+}
+
+func checkpoint(epoch phase0.Epoch, b byte) *phase0.Checkpoint {
+	return &phase0.Checkpoint{
+		Epoch: epoch,
+		Root:  phase0.Root{b},
+	}
+}
+
+func TestFinalityCheckpointsFinalizedUsesFinalizedState(t *testing.T) {
+	head := &v1.Finality{
+		PreviousJustified: checkpoint(11, 0x11),
+		Justified:         checkpoint(12, 0x12),
+		Finalized:         checkpoint(10, 0x10),
+	}
+
+	stateFinality := &v1.Finality{
+		PreviousJustified: checkpoint(9, 0x09),
+		Justified:         checkpoint(10, 0x10),
+		Finalized:         checkpoint(8, 0x08),
+	}
+
+	provider := &fakeFinalityProvider{
+		mode:      beacon.OperatingModeFull,
+		head:      head,
+		finalized: head,
+		states: map[phase0.Root]*spec.VersionedBeaconState{
+			head.Finalized.Root: {
+				Version: spec.DataVersionCapella,
+				Capella: &capella.BeaconState{
+					PreviousJustifiedCheckpoint: stateFinality.PreviousJustified,
+					CurrentJustifiedCheckpoint:  stateFinality.Justified,
+					FinalizedCheckpoint:         stateFinality.Finalized,
+				},
+			},
+		},
+	}
+
+	logger, _ := test.NewNullLogger()
+	handler := NewHandler(logger, provider, "test_finality_checkpoints")
+
+	headID, err := NewStateIdentifier("head")
+	require.NoError(t, err)
+
+	finalizedID, err := NewStateIdentifier("finalized")
+	require.NoError(t, err)
+
+	t.Run("head", func(t *testing.T) {
+		finality, err := handler.FinalityCheckpoints(context.Background(), headID)
+		require.NoError(t, err)
+		assert.Equal(t, head, finality)
+	})
+
+	t.Run("finalized", func(t *testing.T) {
+		finality, err := handler.FinalityCheckpoints(context.Background(), finalizedID)
+		require.NoError(t, err)
+		assert.Equal(t, stateFinality, finality)
+		assert.NotEqual(t, head, finality)
+	})
+
+	t.Run("finalized state missing", func(t *testing.T) {
+		provider.states = map[phase0.Root]*spec.VersionedBeaconState{}
+		defer func() { provider.mode = beacon.OperatingModeFull }()
+
+		_, err := handler.FinalityCheckpoints(context.Background(), finalizedID)
+		assert.Error(t, err)
+
+		// In light mode we don't have states so fall back to the serving bundle.
+		provider.mode = beacon.OperatingModeLight
+
+		finality, err := handler.FinalityCheckpoints(context.Background(), finalizedID)
+		require.NoError(t, err)
+		assert.Equal(t, head, finality)
+	})
+
+	t.Run("light mode without justified checkpoints", func(t *testing.T) {
+		provider.mode = beacon.OperatingModeLight
+		provider.finalized = &v1.Finality{Finalized: head.Finalized}
+
+		defer func() {
+			provider.mode = beacon.OperatingModeFull
+			provider.finalized = head
+		}()
+
+		_, err := handler.FinalityCheckpoints(context.Background(), finalizedID)
+		assert.Error(t, err, "a response without justified checkpoints isn't a valid beacon api response")
+	})
+}